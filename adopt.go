@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// Adopt brings err, created with stdlib (e.g. with fmt.Errorf and %w verb),
+// under this package: if err's chain has no stack trace, Adopt records the
+// stack trace at the point it was called, like WithStack does. If chain
+// already has one, but err itself doesn't support extended format (e.g. it's
+// a stdlib wrapper of this package's error), Adopt only makes %+v print
+// details and stack traces of the chain.
+//
+// Adopted error has the same message, and Is and As still see every error of
+// the original chain, including err itself.
+// If err is nil, Adopt returns nil.
+func Adopt(err error) error {
+	if err == nil {
+		return nil
+	}
+	if Stack(err) == nil {
+		return wStack(err, 1)
+	}
+	if _, ok := err.(fmt.Formatter); ok {
+		return err
+	}
+	return &adopted{err}
+}
+
+// adopted makes %+v print details of err's chain. It's transparent: it has
+// no message, fields and stack trace of its own, so walkers of the chain
+// (Walk, ToJSON, FormatChain, DOT, Diff, etc.) skip it.
+type adopted struct{ error }
+
+func (a *adopted) Unwrap() error   { return a.error }
+func (a *adopted) message() string { return "" }
+
+func (a *adopted) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatDetailed(s, a.error)
+			formatFooter(s, a.error)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, a.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", a.Error())
+	}
+}
+
+// skipAdopted returns the original error, if err was made by Adopt.
+func skipAdopted(err error) error {
+	if a, ok := err.(*adopted); ok {
+		return a.error
+	}
+	return err
+}
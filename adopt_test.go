@@ -0,0 +1,91 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestStackThroughStdlibWrap(t *testing.T) {
+	inner := errors.New("inner")
+	std := fmt.Errorf("ctx: %w", inner)
+
+	require.Equal(t, errors.Stack(inner), errors.Stack(std))
+	require.Equal(t, errors.Stack(inner), errors.Stack(errors.Wrap(std, "outer")))
+	require.Len(t, errors.Stacks(errors.Wrap(std, "outer")), 1)
+}
+
+func TestFormatStdlibWrap(t *testing.T) {
+	std := fmt.Errorf("ctx: %w", errors.New("inner"))
+
+	for _, err := range []error{
+		errors.Wrap(std, "outer"),
+		errors.WithMessage(std, "outer"),
+	} {
+		got := fmt.Sprintf("%+v", err)
+		require.Regexp(t, "^outer: ctx: inner\n"+regexp.QuoteMeta(errors.PkgName)+`\.TestFormatStdlibWrap\n`, got)
+	}
+
+	// without stack trace in the cause there is nothing to expand
+	plain := fmt.Errorf("ctx: %w", io.EOF)
+	require.Equal(t, "outer: ctx: EOF", fmt.Sprintf("%+v", errors.WithMessage(plain, "outer")))
+}
+
+func TestAdopt(t *testing.T) {
+	require.NoError(t, errors.Adopt(nil))
+
+	pathErr := &os.PathError{Op: "open", Path: "/etc/app.yaml", Err: os.ErrNotExist}
+	std := fmt.Errorf("loading config: %w", pathErr)
+
+	err := errors.Adopt(std)
+	require.EqualError(t, err, std.Error())
+	require.NotEmpty(t, errors.Stack(err))
+	require.Equal(t, errors.PkgName+".TestAdopt", funcName(errors.Stack(err)[0]))
+	require.ErrorIs(t, err, std)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	var target *os.PathError
+	require.ErrorAs(t, err, &target)
+	require.Same(t, pathErr, target)
+
+	// errors of this package are returned as is
+	own := errors.New("boom")
+	require.Same(t, own, errors.Adopt(own))
+
+	// stdlib wrapper of error with stack trace only gets extended format
+	inner := errors.New("inner")
+	err = errors.Adopt(fmt.Errorf("ctx: %w", inner))
+	require.Equal(t, errors.Stack(inner), errors.Stack(err))
+	require.Len(t, errors.Stacks(err), 1)
+	require.Regexp(t, "^ctx: inner\n"+regexp.QuoteMeta(errors.PkgName)+`\.TestAdopt\n`, fmt.Sprintf("%+v", err))
+}
+
+func TestAdoptTransparent(t *testing.T) {
+	std := fmt.Errorf("ctx: %w", errors.New("inner"))
+	err := errors.Adopt(std)
+
+	data, jsonErr := errors.ToJSON(err)
+	require.NoError(t, jsonErr)
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, "ctx: inner", res["message"])
+	require.Equal(t, "inner", res["cause"].(map[string]interface{})["message"])
+
+	var walked []error
+	errors.Walk(err, func(e error) bool {
+		walked = append(walked, e)
+		return true
+	})
+	require.Len(t, walked, 2)
+	require.Same(t, std, walked[0])
+
+	require.Regexp(t, `^\*fmt\.wrapError: ctx\n`, errors.FormatChain(err))
+	require.Equal(t, errors.DOT(std), errors.DOT(err))
+	require.Empty(t, errors.Diff(std, err))
+}
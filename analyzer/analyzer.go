@@ -0,0 +1,334 @@
+// Package analyzer provides static analyzer, which checks usage of errors in
+// code, which adopted github.com/quenbyako/errors package:
+//
+//   - calls of Wrap, Wrapf, WithStack and other wrappers with nil error,
+//     which always return nil;
+//   - returning errors created with standard errors.New and fmt.Errorf in
+//     packages, which import github.com/quenbyako/errors, so they lose stack
+//     traces;
+//   - fmt.Errorf calls, which format error argument without %w verb, so
+//     error chain is broken;
+//   - comparisons of errors with == and != (and switch statements over
+//     errors), which don't match wrapped errors, where errors.Is is needed;
+//   - style of error messages: capitalized first word, trailing punctuation
+//     and wrap messages, which duplicate text of wrapped error (e.g.
+//     Wrapf(err, "read: %v", err)). Each style rule can be disabled with
+//     flags -capitalized, -punctuation and -duplicate.
+//
+// Analyzer can be run with go vet, see cmd/errorsvet:
+//
+//	go install github.com/quenbyako/errors/analyzer/cmd/errorsvet@latest
+//	go vet -vettool=$(which errorsvet) ./...
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// PkgPath is import path of errors package, which usage is checked.
+const PkgPath = "github.com/quenbyako/errors"
+
+// Analyzer checks usage of errors, see package documentation.
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorsvet",
+	Doc:      "check usage of errors in packages adopting " + PkgPath,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Style rules, configured by flags of Analyzer.
+var (
+	checkCapitalized bool
+	punctuation      string
+	checkDuplicate   bool
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkCapitalized, "capitalized", true, "report error messages starting with capitalized word (acronyms and identifiers are allowed)")
+	Analyzer.Flags.StringVar(&punctuation, "punctuation", ".!?:;", "report error messages ending with any of these characters, empty disables the rule")
+	Analyzer.Flags.BoolVar(&checkDuplicate, "duplicate", true, "report wrap messages, which include text of wrapped error")
+}
+
+// wrappers are functions of PkgPath, which return nil, if wrapped error is
+// nil, mapped to index of wrapped error argument.
+var wrappers = map[string]int{
+	"Wrap":          0,
+	"Wrapf":         0,
+	"WrapHere":      0,
+	"WrapHeref":     0,
+	"WrapSkip":      0,
+	"Wrapc":         1,
+	"WithStack":     0,
+	"WithStackSkip": 0,
+	"WithMessage":   0,
+	"WithMessagef":  0,
+}
+
+// messageArgs are functions, which create or wrap errors, mapped to index of
+// message (or format) argument.
+var messageArgs = map[string]map[string]int{
+	PkgPath: {
+		"New":          0,
+		"NewSkip":      0,
+		"Errorf":       0,
+		"Wrap":         1,
+		"Wrapf":        1,
+		"WrapHere":     1,
+		"WrapHeref":    1,
+		"WrapSkip":     1,
+		"Wrapc":        2,
+		"WithMessage":  1,
+		"WithMessagef": 1,
+	},
+	"errors": {"New": 0},
+	"fmt":    {"Errorf": 0},
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	adopted := imports(pass.Pkg, PkgPath)
+
+	// returned stdlib errors are reported once, even if they also miss %w
+	reported := make(map[*ast.CallExpr]bool)
+
+	nodes := []ast.Node{
+		(*ast.ReturnStmt)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+	ins.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ReturnStmt:
+			if adopted {
+				checkReturn(pass, n, reported)
+			}
+		case *ast.CallExpr:
+			checkWrapNil(pass, n)
+			checkMessage(pass, n)
+			if !reported[n] {
+				checkErrorf(pass, n)
+			}
+		case *ast.BinaryExpr:
+			if !inIsMethod(stack) {
+				checkComparison(pass, n)
+			}
+		case *ast.SwitchStmt:
+			if !inIsMethod(stack) {
+				checkSwitch(pass, n)
+			}
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+func imports(pkg *types.Package, path string) bool {
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == path {
+			return true
+		}
+	}
+	return false
+}
+
+// calledFunc returns package path and name of package-level function called
+// in call.
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) (pkg, name string) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return "", ""
+	}
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		return "", ""
+	}
+	return fn.Pkg().Path(), fn.Name()
+}
+
+func checkReturn(pass *analysis.Pass, ret *ast.ReturnStmt, reported map[*ast.CallExpr]bool) {
+	for _, res := range ret.Results {
+		call, ok := ast.Unparen(res).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		switch pkg, name := calledFunc(pass, call); {
+		case pkg == "errors" && name == "New", pkg == "fmt" && name == "Errorf":
+			reported[call] = true
+			pass.Reportf(call.Pos(), "returned error is created with %s.%s without stack trace, use %s.%s", pkg, name, PkgPath, name)
+		}
+	}
+}
+
+func checkWrapNil(pass *analysis.Pass, call *ast.CallExpr) {
+	pkg, name := calledFunc(pass, call)
+	arg, ok := wrappers[name]
+	if pkg != PkgPath || !ok {
+		return
+	}
+	if len(call.Args) > arg && isNil(pass, call.Args[arg]) {
+		pass.Reportf(call.Pos(), "%s with nil error always returns nil", name)
+	}
+}
+
+func checkErrorf(pass *analysis.Pass, call *ast.CallExpr) {
+	pkg, name := calledFunc(pass, call)
+	if pkg != "fmt" || name != "Errorf" || len(call.Args) < 2 {
+		return
+	}
+	format := pass.TypesInfo.Types[call.Args[0]].Value
+	if format == nil || format.Kind() != constant.String {
+		return
+	}
+	if strings.Contains(constant.StringVal(format), "%w") {
+		return
+	}
+	for _, arg := range call.Args[1:] {
+		if isError(pass, arg) {
+			pass.Reportf(call.Pos(), "fmt.Errorf formats error argument without %%w, so it can't be unwrapped")
+			return
+		}
+	}
+}
+
+func checkComparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+	if isNil(pass, expr.X) || isNil(pass, expr.Y) || !isError(pass, expr.X) || !isError(pass, expr.Y) {
+		return
+	}
+	pass.Reportf(expr.OpPos, "comparison of errors with %s doesn't match wrapped errors, use errors.Is", expr.Op)
+}
+
+func checkSwitch(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if stmt.Tag == nil || !isError(pass, stmt.Tag) {
+		return
+	}
+	for _, clause := range stmt.Body.List {
+		for _, value := range clause.(*ast.CaseClause).List {
+			if !isNil(pass, value) {
+				pass.Reportf(value.Pos(), "switch on error compares it with ==, which doesn't match wrapped errors, use errors.Is")
+			}
+		}
+	}
+}
+
+// inIsMethod reports whether node is inside Is method, where comparison of
+// errors implements errors.Is itself.
+func inIsMethod(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if fn, ok := stack[i].(*ast.FuncDecl); ok {
+			return fn.Recv != nil && fn.Name.Name == "Is"
+		}
+	}
+	return false
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	return pass.TypesInfo.Types[expr].IsNil()
+}
+
+func isError(pass *analysis.Pass, expr ast.Expr) bool {
+	t := pass.TypesInfo.TypeOf(expr)
+	return t != nil && types.Implements(t, errorType)
+}
+
+func checkMessage(pass *analysis.Pass, call *ast.CallExpr) {
+	pkg, name := calledFunc(pass, call)
+	arg, ok := messageArgs[pkg][name]
+	if !ok || len(call.Args) <= arg {
+		return
+	}
+
+	if checkDuplicate && pkg == PkgPath {
+		if wrapped, ok := wrappers[name]; ok && wrapped < arg {
+			checkDuplicateMessage(pass, call, call.Args[wrapped], call.Args[arg:])
+		}
+	}
+
+	value := pass.TypesInfo.Types[call.Args[arg]].Value
+	if value == nil || value.Kind() != constant.String {
+		return
+	}
+	msg := constant.StringVal(value)
+
+	if checkCapitalized && isCapitalized(msg) {
+		pass.Reportf(call.Args[arg].Pos(), "error message should not be capitalized")
+	}
+	if r, _ := utf8.DecodeLastRuneInString(msg); msg != "" && strings.ContainsRune(punctuation, r) {
+		pass.Reportf(call.Args[arg].Pos(), "error message should not end with punctuation")
+	}
+}
+
+// isCapitalized reports whether first word of msg is capitalized ordinary
+// word. Acronyms ("HTTP"), identifiers ("EOF", "Decoder.Decode") and
+// single letters are allowed.
+func isCapitalized(msg string) bool {
+	word := msg
+	if i := strings.IndexFunc(msg, unicode.IsSpace); i >= 0 {
+		word = msg[:i]
+	}
+	word = strings.TrimRightFunc(word, unicode.IsPunct)
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) || len(word) == size {
+		return false
+	}
+	for _, r := range word[size:] {
+		if !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDuplicateMessage reports message arguments of wrapper, which include
+// wrapped error itself, e.g. Wrapf(err, "read: %v", err) or
+// Wrap(err, "read: "+err.Error()), so its text is printed twice.
+func checkDuplicateMessage(pass *analysis.Pass, call *ast.CallExpr, wrapped ast.Expr, args []ast.Expr) {
+	obj := referencedObject(pass, wrapped)
+	if obj == nil {
+		return
+	}
+	for _, arg := range args {
+		found := false
+		ast.Inspect(arg, func(n ast.Node) bool {
+			if e, ok := n.(ast.Expr); ok && !found && referencedObject(pass, e) == obj {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			pass.Reportf(arg.Pos(), "wrap message duplicates text of wrapped error, which is already appended to it")
+			return
+		}
+	}
+}
+
+// referencedObject returns variable referenced by identifier expr, or nil.
+func referencedObject(pass *analysis.Pass, expr ast.Expr) types.Object {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/quenbyako/errors/analyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "adopted", "plain", "style")
+}
+
+func TestAnalyzerStyleFlags(t *testing.T) {
+	setFlag(t, "capitalized", "false")
+	setFlag(t, "punctuation", "!")
+	setFlag(t, "duplicate", "false")
+
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "stylecustom")
+}
+
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	f := analyzer.Analyzer.Flags.Lookup(name)
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
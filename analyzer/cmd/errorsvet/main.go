@@ -0,0 +1,13 @@
+// Command errorsvet runs analyzer of github.com/quenbyako/errors usage as
+// go vet tool:
+//
+//	go vet -vettool=$(which errorsvet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/quenbyako/errors/analyzer"
+)
+
+func main() { unitchecker.Main(analyzer.Analyzer) }
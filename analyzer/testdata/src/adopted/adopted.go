@@ -0,0 +1,51 @@
+package adopted
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+
+	"github.com/quenbyako/errors"
+)
+
+var ErrNotFound = errors.New("not found")
+
+func load(name string) error {
+	if name == "" {
+		return stderrors.New("empty name") // want `returned error is created with errors.New without stack trace, use github.com/quenbyako/errors.New`
+	}
+	if name == "-" {
+		return fmt.Errorf("invalid name %q: %v", name, io.EOF) // want `returned error is created with fmt.Errorf without stack trace, use github.com/quenbyako/errors.Errorf`
+	}
+	err := fmt.Errorf("load %s: %v", name, io.EOF) // want `fmt.Errorf formats error argument without %w, so it can't be unwrapped`
+	if err != nil {
+		return errors.Wrap(err, "load")
+	}
+	return errors.New("ok")
+}
+
+func wrapNil(ctx context.Context) error {
+	_ = errors.Wrap(nil, "message")       // want `Wrap with nil error always returns nil`
+	_ = errors.Wrapc(ctx, nil, "message") // want `Wrapc with nil error always returns nil`
+	_ = errors.WithStack(nil)             // want `WithStack with nil error always returns nil`
+	return errors.Wrapf(load(""), "wrap %d", 1)
+}
+
+func compare(err error) bool {
+	if err == io.EOF { // want `comparison of errors with == doesn't match wrapped errors, use errors.Is`
+		return true
+	}
+	switch err {
+	case nil:
+	case ErrNotFound: // want `switch on error compares it with ==, which doesn't match wrapped errors, use errors.Is`
+		return true
+	}
+	return errors.Is(err, ErrNotFound) || err != nil
+}
+
+type notFound struct{}
+
+func (notFound) Error() string { return "not found" }
+
+func (notFound) Is(target error) bool { return target == ErrNotFound }
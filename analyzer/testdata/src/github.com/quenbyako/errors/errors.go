@@ -0,0 +1,12 @@
+// Package errors is a stub of github.com/quenbyako/errors for analyzer tests.
+package errors
+
+import "context"
+
+func New(message string) error                                   { return nil }
+func Errorf(format string, args ...interface{}) error            { return nil }
+func Wrap(err error, message string) error                       { return err }
+func Wrapf(err error, format string, args ...interface{}) error  { return err }
+func Wrapc(ctx context.Context, err error, message string) error { return err }
+func WithStack(err error) error                                  { return err }
+func Is(err, target error) bool                                  { return err == target }
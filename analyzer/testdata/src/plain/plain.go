@@ -0,0 +1,22 @@
+package plain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errEmpty = errors.New("empty")
+
+func check(name string) error {
+	if name == "" {
+		return errEmpty
+	}
+	if name == "-" {
+		return errors.New("dash")
+	}
+	return fmt.Errorf("check %s: %w", name, errEmpty)
+}
+
+func describe(err error) string {
+	return fmt.Errorf("failed: %v", err).Error() // want `fmt.Errorf formats error argument without %w, so it can't be unwrapped`
+}
@@ -0,0 +1,34 @@
+package style
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/quenbyako/errors"
+)
+
+var (
+	errCapital  = stderrors.New("Something failed") // want `error message should not be capitalized`
+	errAcronym  = stderrors.New("HTTP request failed")
+	errIdent    = stderrors.New("EOF reached")
+	errMethod   = stderrors.New("Decoder.Decode: bad input")
+	errPunct    = errors.New("something failed.")  // want `error message should not end with punctuation`
+	errColon    = fmt.Errorf("read %s:", "file")   // want `error message should not end with punctuation`
+	errBoth     = errors.New("Failed!")            // want `error message should not be capitalized` `error message should not end with punctuation`
+	errFormat   = errors.Errorf("Read %s", "file") // want `error message should not be capitalized`
+	errSingle   = errors.New("X is invalid")
+	errNoDetail = errors.New("")
+)
+
+func wrap(err error, name string) error {
+	if name == "" {
+		return errors.Wrap(err, "Open config") // want `error message should not be capitalized`
+	}
+	if name == "-" {
+		return errors.Wrapf(err, "read %s: %v", name, err) // want `wrap message duplicates text of wrapped error, which is already appended to it`
+	}
+	if name == "+" {
+		return errors.Wrap(err, "read: "+err.Error()) // want `wrap message duplicates text of wrapped error, which is already appended to it`
+	}
+	return errors.Wrapf(err, "read %s", name)
+}
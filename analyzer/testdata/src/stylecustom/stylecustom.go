@@ -0,0 +1,13 @@
+package stylecustom
+
+import "github.com/quenbyako/errors"
+
+var (
+	errCapital = errors.New("Something failed")
+	errPunct   = errors.New("something failed.")
+	errBang    = errors.New("something failed!") // want `error message should not end with punctuation`
+)
+
+func wrap(err error) error {
+	return errors.Wrapf(err, "read: %v", err)
+}
@@ -110,3 +110,86 @@ func BenchmarkStackFormatting(b *testing.B) {
 	}
 	GlobalE = stackStr
 }
+
+func BenchmarkStackFrames(b *testing.B) {
+	stack := errors.Stack(ownErrors(0, 30))
+
+	var name string
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for frames := stack.Frames(); frames.Next(); {
+			_, _, name = frames.FuncInfo()
+		}
+	}
+	b.StopTimer()
+	GlobalE = name
+}
+
+func BenchmarkStackPooling(b *testing.B) {
+	runs := []struct {
+		name    string
+		pooling bool
+		arena   int
+	}{
+		{"none", false, 0},
+		{"pool", true, 0},
+		{"arena", false, 4096},
+		{"pool-arena", true, 4096},
+	}
+	for _, r := range runs {
+		b.Run(r.name, func(b *testing.B) {
+			errors.SetDefaultDepth(64)
+			errors.SetStackPooling(r.pooling)
+			errors.SetStackArena(r.arena)
+			defer errors.SetDefaultDepth(0)
+			defer errors.SetStackPooling(false)
+			defer errors.SetStackArena(0)
+
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if ownErrors(0, 100) == nil {
+						b.Fail()
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkSymbolCache(b *testing.B) {
+	for _, size := range []int{0, 1024} {
+		b.Run(fmt.Sprintf("size-%d", size), func(b *testing.B) {
+			errors.SetSymbolCache(size)
+			defer errors.SetSymbolCache(0)
+
+			stack := errors.Stack(ownErrors(0, 30))
+			var str string
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				str = fmt.Sprintf("%+v", stack)
+			}
+			b.StopTimer()
+			GlobalE = str
+		})
+	}
+}
+
+func BenchmarkNoStackTrace(b *testing.B) {
+	for _, noStack := range []bool{false, true} {
+		b.Run(fmt.Sprintf("no-stack-%v", noStack), func(b *testing.B) {
+			var opts []errors.Option
+			if noStack {
+				opts = append(opts, errors.NoStackTrace())
+			}
+			var err error
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err = errors.NewWithOptions("error", opts...)
+			}
+			b.StopTimer()
+			GlobalE = err
+		})
+	}
+}
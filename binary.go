@@ -0,0 +1,305 @@
+package errors
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+)
+
+// binaryMagic starts binary representation of errors, last byte is a
+// version of format.
+const binaryMagic = "QE\x05"
+
+// Encode returns compact binary representation of err's chain. It contains
+// the same data as ToJSON representation (messages, stack frames, codes,
+// kinds, severities, build info, hints and fields), but repeated strings (e.g. file and function names of
+// frames) are stored only once, so it's suitable to persist errors in job
+// queues and dead letter queues. Field values are encoded as JSON.
+// If err is nil, Encode returns nil.
+func Encode(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	e := &encoder{index: make(map[string]uint64)}
+	if err := e.node(toJSONError(err)); err != nil {
+		return nil, err
+	}
+
+	buf := append([]byte(binaryMagic), uvarint(uint64(len(e.strings)))...)
+	for _, s := range e.strings {
+		buf = append(buf, uvarint(uint64(len(s)))...)
+		buf = append(buf, s...)
+	}
+	return append(buf, e.buf...), nil
+}
+
+// Decode reconstructs error chain from its binary representation, produced
+// by Encode. Reconstructed errors behave the same way as errors,
+// reconstructed by FromJSON.
+// If data is empty, Decode returns nil error.
+func Decode(data []byte) (error, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if len(data) < len(binaryMagic) || string(data[:len(binaryMagic)]) != binaryMagic {
+		return nil, New("invalid binary error: unknown format")
+	}
+	d := &decoder{buf: data[len(binaryMagic):]}
+	n := d.uvarint()
+	if n > uint64(len(d.buf)) {
+		return nil, New("invalid binary error: corrupted string table")
+	}
+	d.strings = make([]string, n)
+	for i := range d.strings {
+		d.strings[i] = string(d.bytes())
+	}
+	e := d.node()
+	if d.err != nil {
+		return nil, d.err
+	}
+	return fromJSONError(e), nil
+}
+
+// maxBinaryDepth is maximum nesting of causes, accepted by Decode, so
+// crafted data can't exhaust goroutine stack.
+const maxBinaryDepth = 1000
+
+const (
+	binaryNoCause = iota
+	binaryCause
+	binaryCauses
+)
+
+type encoder struct {
+	strings []string
+	index   map[string]uint64
+	buf     []byte
+}
+
+func uvarint(v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return b[:binary.PutUvarint(b[:], v)]
+}
+
+func (e *encoder) uvarint(v uint64) { e.buf = append(e.buf, uvarint(v)...) }
+
+func (e *encoder) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutVarint(b[:], v)]...)
+}
+
+func (e *encoder) string(s string) {
+	i, ok := e.index[s]
+	if !ok {
+		i = uint64(len(e.strings))
+		e.strings = append(e.strings, s)
+		e.index[s] = i
+	}
+	e.uvarint(i)
+}
+
+func (e *encoder) frame(f Frame) {
+	file, line, name := f.FuncInfo()
+	e.string(name)
+	e.string(file)
+	e.uvarint(uint64(line))
+}
+
+func (e *encoder) node(n *jsonError) error {
+	e.string(n.Message)
+	e.uvarint(uint64(len(n.Stack)))
+	for _, f := range n.Stack {
+		e.frame(f)
+	}
+	if n.At == 0 {
+		e.uvarint(0)
+	} else {
+		e.uvarint(1)
+		e.frame(n.At)
+	}
+	if n.Code == nil {
+		e.uvarint(0)
+	} else {
+		e.uvarint(1)
+		e.varint(int64(*n.Code))
+	}
+	e.string(n.Kind)
+	e.string(n.Hint)
+	e.string(n.Level)
+	if n.Build == nil {
+		e.uvarint(0)
+	} else {
+		e.uvarint(1)
+		e.string(n.Build.Path)
+		e.string(n.Build.Version)
+		e.string(n.Build.Revision)
+		if n.Build.Dirty {
+			e.uvarint(1)
+		} else {
+			e.uvarint(0)
+		}
+	}
+
+	keys := make([]string, 0, len(n.Fields))
+	for k := range n.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	e.uvarint(uint64(len(keys)))
+	for _, k := range keys {
+		value, err := json.Marshal(n.Fields[k])
+		if err != nil {
+			return Wrapf(err, "encoding field %q", k)
+		}
+		e.string(k)
+		e.uvarint(uint64(len(value)))
+		e.buf = append(e.buf, value...)
+	}
+
+	switch {
+	case len(n.Causes) > 0:
+		e.uvarint(binaryCauses)
+		e.uvarint(uint64(len(n.Causes)))
+		for _, c := range n.Causes {
+			if err := e.node(c); err != nil {
+				return err
+			}
+		}
+	case n.Cause != nil:
+		e.uvarint(binaryCause)
+		return e.node(n.Cause)
+	default:
+		e.uvarint(binaryNoCause)
+	}
+	return nil
+}
+
+type decoder struct {
+	strings []string
+	buf     []byte
+	depth   int
+	err     error
+}
+
+func (d *decoder) fail(msg string) {
+	if d.err == nil {
+		d.err = New("invalid binary error: " + msg)
+	}
+	d.buf = nil
+}
+
+func (d *decoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.fail("malformed varint")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.fail("malformed varint")
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.uvarint()
+	if n > uint64(len(d.buf)) {
+		d.fail("unexpected end of data")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) string() string {
+	i := d.uvarint()
+	if i >= uint64(len(d.strings)) {
+		d.fail("string index out of range")
+		return ""
+	}
+	return d.strings[i]
+}
+
+func (d *decoder) frame() Frame {
+	name := d.string()
+	file := d.string()
+	line := d.uvarint()
+	if d.err != nil {
+		return 0
+	}
+	return syntheticFrame(file, int(line), name)
+}
+
+func (d *decoder) node() *jsonError {
+	if d.depth++; d.depth > maxBinaryDepth {
+		d.fail("too deep nesting of causes")
+		return nil
+	}
+	defer func() { d.depth-- }()
+
+	n := &jsonError{Message: d.string()}
+	if count := d.uvarint(); count > 0 && count <= uint64(len(d.buf)) {
+		n.Stack = make(StackTrace, count)
+		for i := range n.Stack {
+			n.Stack[i] = d.frame()
+		}
+	} else if count > 0 {
+		d.fail("unexpected end of data")
+	}
+	if d.uvarint() == 1 {
+		n.At = d.frame()
+	}
+	if d.uvarint() == 1 {
+		code := int(d.varint())
+		n.Code = &code
+	}
+	n.Kind = d.string()
+	n.Hint = d.string()
+	n.Level = d.string()
+	if d.uvarint() == 1 {
+		n.Build = &BuildInfo{
+			Path:     d.string(),
+			Version:  d.string(),
+			Revision: d.string(),
+			Dirty:    d.uvarint() == 1,
+		}
+	}
+	if count := d.uvarint(); count > 0 && count <= uint64(len(d.buf)) {
+		n.Fields = make(map[string]interface{}, count)
+		for i := uint64(0); i < count && d.err == nil; i++ {
+			k := d.string()
+			var v interface{}
+			if err := json.Unmarshal(d.bytes(), &v); err != nil && d.err == nil {
+				d.fail("malformed field " + k)
+			}
+			n.Fields[k] = v
+		}
+	} else if count > 0 {
+		d.fail("unexpected end of data")
+	}
+
+	switch d.uvarint() {
+	case binaryNoCause:
+	case binaryCause:
+		n.Cause = d.node()
+	case binaryCauses:
+		count := d.uvarint()
+		for i := uint64(0); i < count && d.err == nil; i++ {
+			n.Causes = append(n.Causes, d.node())
+		}
+	default:
+		d.fail("unknown cause kind")
+	}
+	if d.err != nil {
+		return nil
+	}
+	return n
+}
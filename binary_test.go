@@ -0,0 +1,111 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"new", errors.New("error")},
+		{"foreign", io.EOF},
+		{"wrapped", errors.Wrap(errors.WithField(errors.WithCode(errors.New("not found"), -404), "id", 42), "get user")},
+		{"joined", errors.Join(errors.New("first"), errors.Wrap(io.EOF, "second"))},
+		{"severity", errors.Wrap(errors.WithSeverity(errors.New("slow"), errors.LevelWarning), "call")},
+		{"build", errors.Wrap(errors.WithBuildInfo(io.EOF, errors.BuildInfo{Path: "example.com/app", Version: "v1.2.3", Revision: "0123abc", Dirty: true}), "call")},
+		{"hinted", errors.WithHint(errors.Wrap(errors.WithHint(io.EOF, "check network"), "read"), "retry later")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := errors.Encode(tt.err)
+			require.NoError(t, err)
+
+			got, err := errors.Decode(data)
+			require.NoError(t, err)
+
+			want, err := errors.FromJSON(mustJSON(t, tt.err))
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("%+v", want), fmt.Sprintf("%+v", got))
+			require.Equal(t, errors.Fields(want), errors.Fields(got))
+			require.Equal(t, len(errors.Stacks(tt.err)), len(errors.Stacks(got)))
+			require.Equal(t, errors.Hints(tt.err), errors.Hints(got))
+			require.Equal(t, errors.Severity(tt.err), errors.Severity(got))
+
+			wantInfo, wantOk := errors.BuildInfoOf(tt.err)
+			info, ok := errors.BuildInfoOf(got)
+			require.Equal(t, wantInfo, info)
+			require.Equal(t, wantOk, ok)
+
+			wantCode, wantOk := errors.Code(tt.err)
+			code, ok := errors.Code(got)
+			require.Equal(t, wantCode, code)
+			require.Equal(t, wantOk, ok)
+		})
+	}
+}
+
+func TestEncodeCompact(t *testing.T) {
+	err := errors.Wrap(errors.Wrap(errors.New("error"), "second"), "first")
+
+	data, encErr := errors.Encode(err)
+	require.NoError(t, encErr)
+	require.Less(t, len(data), len(mustJSON(t, err)))
+}
+
+func TestEncodeNil(t *testing.T) {
+	data, err := errors.Encode(nil)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	got, err := errors.Decode(nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	data, err := errors.Encode(errors.WithField(errors.New("error"), "id", 42))
+	require.NoError(t, err)
+
+	for i := 1; i < len(data); i++ {
+		_, err := errors.Decode(data[:i])
+		require.Error(t, err, "truncated at %d", i)
+	}
+
+	_, err = errors.Decode([]byte("{}"))
+	require.EqualError(t, err, "invalid binary error: unknown format")
+
+	// version 1 had no kinds, version 2 had no hints, version 3 had no
+	// severities and version 4 had no build info
+	for _, version := range []string{"QE\x01", "QE\x02", "QE\x03", "QE\x04"} {
+		_, err = errors.Decode(append([]byte(version), data[3:]...))
+		require.EqualError(t, err, "invalid binary error: unknown format")
+	}
+}
+
+func TestDecodeTooDeep(t *testing.T) {
+	err := errors.New("error")
+	for i := 0; i < 1000; i++ {
+		err = errors.WithMessage(err, "wrap")
+	}
+	data, encErr := errors.Encode(err)
+	require.NoError(t, encErr)
+
+	_, err = errors.Decode(data)
+	require.EqualError(t, err, "invalid binary error: too deep nesting of causes")
+}
+
+func mustJSON(t *testing.T, err error) []byte {
+	t.Helper()
+	data, jsonErr := errors.ToJSON(err)
+	require.NoError(t, jsonErr)
+	return data
+}
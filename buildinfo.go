@@ -0,0 +1,148 @@
+package errors
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+)
+
+// BuildInfo identifies binary, which emitted an error, so error reports,
+// received from other services, can be attributed to exact build.
+type BuildInfo struct {
+	// Path is a path of the main module.
+	Path string `json:"path,omitempty"`
+	// Version is a version of the main module, "(devel)" for local builds.
+	Version string `json:"version,omitempty"`
+	// Revision is a VCS revision, the binary was built from.
+	Revision string `json:"revision,omitempty"`
+	// Dirty reports whether working tree had local modifications.
+	Dirty bool `json:"dirty,omitempty"`
+}
+
+// String returns build info in the form of
+// "example.com/app@v1.2.3 (rev 0123abc, dirty)".
+func (b BuildInfo) String() string {
+	s := b.Path
+	if b.Version != "" {
+		s += "@" + b.Version
+	}
+	var details []string
+	if b.Revision != "" {
+		details = append(details, "rev "+b.Revision)
+	}
+	if b.Dirty {
+		details = append(details, "dirty")
+	}
+	if len(details) > 0 {
+		s += " (" + strings.Join(details, ", ") + ")"
+	}
+	return s
+}
+
+// ReadBuildInfo returns build info of current binary, read with
+// debug.ReadBuildInfo. If binary was built without module support, it
+// returns zero BuildInfo.
+func ReadBuildInfo() BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{}
+	}
+	res := BuildInfo{Path: info.Main.Path, Version: info.Main.Version}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			res.Revision = s.Value
+		case "vcs.modified":
+			res.Dirty = s.Value == "true"
+		}
+	}
+	return res
+}
+
+type buildInfoHolder struct{ info *BuildInfo }
+
+var processBuildInfo atomic.Value // buildInfoHolder
+
+// SetBuildInfo makes constructors of this package, which record stack trace
+// (New, Errorf, Wrap, WithStack, etc.), to stamp errors with info, unless
+// they already have build info in their chain (e.g. were received from
+// another service). Zero info disables stamping. Usually it's called once
+// at startup:
+//
+//	errors.SetBuildInfo(errors.ReadBuildInfo())
+func SetBuildInfo(info BuildInfo) {
+	var h buildInfoHolder
+	if info != (BuildInfo{}) {
+		h.info = &info
+	}
+	processBuildInfo.Store(h)
+}
+
+func loadBuildInfo() *BuildInfo {
+	h, _ := processBuildInfo.Load().(buildInfoHolder)
+	return h.info
+}
+
+type withBuildInfo struct {
+	cause error
+	info  BuildInfo
+}
+
+// WithBuildInfo stamps err with build info, which can be extracted later
+// with BuildInfoOf. Build info is serialized by ToJSON and printed at the
+// end of extended format (%+v).
+// If err is nil, WithBuildInfo returns nil.
+func WithBuildInfo(err error, info BuildInfo) error {
+	if err == nil {
+		return nil
+	}
+	return &withBuildInfo{cause: err, info: info}
+}
+
+func (w *withBuildInfo) Error() string   { return w.cause.Error() }
+func (w *withBuildInfo) Unwrap() error   { return w.cause }
+func (w *withBuildInfo) message() string { return "" }
+
+func (w *withBuildInfo) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		formatDetailed(s, w.cause)
+		formatFooter(s, w)
+		return
+	}
+	formatTransparent(s, verb, w.cause)
+}
+
+func (w *withBuildInfo) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// footerBuildInfo returns build info of err, printed at the end of extended
+// format: build info of err's chain (see BuildInfoOf), or the first one in
+// err's tree, if err is a multi-error.
+func footerBuildInfo(err error) (BuildInfo, bool) {
+	if info, ok := BuildInfoOf(err); ok {
+		return info, true
+	}
+	var res BuildInfo
+	found := false
+	walk(err, func(e error) bool {
+		if w, ok := e.(*withBuildInfo); ok {
+			res, found = w.info, true
+		}
+		return !found
+	})
+	return res, found
+}
+
+// BuildInfoOf returns build info of the binary, where err originated: the
+// innermost build info in err's chain (see WithBuildInfo and SetBuildInfo).
+// If there is no build info, BuildInfoOf returns false.
+func BuildInfoOf(err error) (BuildInfo, bool) {
+	var res BuildInfo
+	found := false
+	for ; err != nil; err = Unwrap(err) {
+		if w, ok := err.(*withBuildInfo); ok {
+			res, found = w.info, true
+		}
+	}
+	return res, found
+}
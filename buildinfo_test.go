@@ -0,0 +1,70 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestBuildInfo(t *testing.T) {
+	info := errors.BuildInfo{Path: "example.com/app", Version: "v1.2.3", Revision: "0123abc", Dirty: true}
+	require.Equal(t, "example.com/app@v1.2.3 (rev 0123abc, dirty)", info.String())
+	require.Equal(t, "example.com/app", errors.BuildInfo{Path: "example.com/app"}.String())
+
+	require.Nil(t, errors.WithBuildInfo(nil, info))
+	_, ok := errors.BuildInfoOf(io.EOF)
+	require.False(t, ok)
+
+	err := errors.Wrap(errors.WithBuildInfo(errors.New("boom"), info), "handle")
+	got, ok := errors.BuildInfoOf(err)
+	require.True(t, ok)
+	require.Equal(t, info, got)
+	require.Equal(t, "handle: boom", err.Error())
+	require.True(t, strings.HasSuffix(fmt.Sprintf("%+v", errors.WithBuildInfo(io.EOF, info)), "\nbuild: example.com/app@v1.2.3 (rev 0123abc, dirty)"))
+
+	data, jerr := errors.ToJSON(err)
+	require.NoError(t, jerr)
+	require.Contains(t, string(data), `"build":{"path":"example.com/app","version":"v1.2.3","revision":"0123abc","dirty":true}`)
+	back, jerr := errors.FromJSON(data)
+	require.NoError(t, jerr)
+	got, ok = errors.BuildInfoOf(back)
+	require.True(t, ok)
+	require.Equal(t, info, got)
+}
+
+func TestSetBuildInfo(t *testing.T) {
+	errors.SetBuildInfo(errors.ReadBuildInfo())
+	defer errors.SetBuildInfo(errors.BuildInfo{})
+
+	info, ok := errors.BuildInfoOf(errors.New("boom"))
+	require.True(t, ok)
+	require.Equal(t, errors.ReadBuildInfo(), info)
+
+	remote := errors.BuildInfo{Path: "example.com/other"}
+	err := errors.Wrap(errors.WithBuildInfo(io.EOF, remote), "call")
+	info, _ = errors.BuildInfoOf(err)
+	require.Equal(t, remote, info, "build info of origin is kept")
+
+	errors.SetBuildInfo(errors.BuildInfo{})
+	_, ok = errors.BuildInfoOf(errors.New("boom"))
+	require.False(t, ok)
+}
+
+func TestBuildInfoFooter(t *testing.T) {
+	errors.SetBuildInfo(errors.BuildInfo{Path: "example.com/app", Version: "v1.2.3"})
+	defer errors.SetBuildInfo(errors.BuildInfo{})
+
+	for _, err := range []error{
+		errors.Wrap(errors.New("x"), "y"),
+		errors.Join(errors.New("a"), errors.Wrap(errors.New("b"), "c")),
+	} {
+		got := fmt.Sprintf("%+v", err)
+		require.Equal(t, 1, strings.Count(got, "build: "), got)
+		require.True(t, strings.HasSuffix(got, "\nbuild: example.com/app@v1.2.3"), got)
+	}
+}
@@ -0,0 +1,34 @@
+package errors
+
+import "sync/atomic"
+
+type callersFuncHolder struct {
+	fn func(skip uint) StackTrace
+}
+
+var callersFunc atomic.Value // callersFuncHolder
+
+// SetCallersFunc replaces function, which records stack traces (and wrap
+// points) of errors, created by this package. It's a test hook: fn can
+// return deterministic fake stacks, so formatted output can be compared with
+// golden files without matching line numbers:
+//
+//	errors.SetCallersFunc(func(uint) errors.StackTrace {
+//		return errors.StackTraceFromRuntime([]runtime.Frame{
+//			{Function: "main.load", File: "/app/config.go", Line: 42},
+//		})
+//	})
+//	defer errors.SetCallersFunc(nil)
+//
+// skip is the argument for runtime.Callers, called directly by fn, to record
+// the real stack starting from the caller of this package. Returned stack is
+// truncated to configured depth (see SetDefaultDepth). Nil fn restores the
+// default behavior.
+func SetCallersFunc(fn func(skip uint) StackTrace) {
+	callersFunc.Store(callersFuncHolder{fn: fn})
+}
+
+func loadCallersFunc() func(skip uint) StackTrace {
+	h, _ := callersFunc.Load().(callersFuncHolder)
+	return h.fn
+}
@@ -0,0 +1,40 @@
+package errors_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetCallersFunc(t *testing.T) {
+	defer errors.SetCallersFunc(nil)
+
+	fake := errors.StackTraceFromRuntime([]runtime.Frame{
+		{Function: "main.load", File: "/app/config.go", Line: 42},
+		{Function: "main.main", File: "/app/main.go", Line: 12},
+	})
+	errors.SetCallersFunc(func(uint) errors.StackTrace { return fake })
+
+	err := errors.New("file not found")
+	require.Equal(t, "file not found\nmain.load\n\t/app/config.go:42\nmain.main\n\t/app/main.go:12\n", fmt.Sprintf("%+v", err))
+
+	errors.SetDefaultDepth(1)
+	defer errors.SetDefaultDepth(32)
+	require.Len(t, errors.Stack(errors.New("boom")), 1)
+	errors.SetDefaultDepth(32)
+
+	// skip lets hook record real stack
+	errors.SetCallersFunc(func(skip uint) errors.StackTrace {
+		pcs := make([]uintptr, 32)
+		return errors.StackTraceFromPCs(pcs[:runtime.Callers(int(skip), pcs)])
+	})
+	require.Equal(t, errors.PkgName+".TestSetCallersFunc", funcName(errors.Stack(errors.New("boom"))[0]))
+	require.Equal(t, errors.PkgName+".TestSetCallersFunc", funcName(errors.Stack(errors.WithStack(fmt.Errorf("boom")))[0]))
+
+	errors.SetCallersFunc(nil)
+	require.Equal(t, errors.PkgName+".TestSetCallersFunc", funcName(errors.Stack(errors.New("boom"))[0]))
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatChain renders every error in err's chain as its own block with type
+// name, own message, attached fields and stack trace (if any). Blocks are
+// separated by "--- caused by ---" lines, branches of multi-errors are
+// rendered as indented nested chains:
+//
+//	*errors.withMessage: read config
+//	    at main.load
+//	    	/src/main.go:42
+//	--- caused by ---
+//	*errors.fundamental: file not found
+//	    main.open
+//	    	/src/main.go:12
+//	    ...
+//
+// If err is nil, FormatChain returns empty string.
+func FormatChain(err error) string {
+	var buf bytes.Buffer
+	formatChain(&buf, err)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func formatChain(buf *bytes.Buffer, err error) {
+	for first := true; err != nil; first = false {
+		err = skipAdopted(err)
+		if !first {
+			buf.WriteString("--- caused by ---\n")
+		}
+		fmt.Fprintf(buf, "%T", err)
+		if msg := ownMessage(err); msg != "" {
+			buf.WriteString(": " + msg)
+		}
+		buf.WriteByte('\n')
+
+		if f, ok := err.(interface{ fields() []Field }); ok && len(f.fields()) > 0 {
+			buf.WriteString("    fields: " + formatFields(f.fields()) + "\n")
+		}
+		if w, ok := err.(interface{ wrappedAt() Frame }); ok && w.wrappedAt() != 0 {
+			buf.WriteString(indent(fmt.Sprintf("at %+v\n", w.wrappedAt()), "    "))
+		}
+		if stack, ok := stackOf(err); ok && len(stack) > 0 {
+			buf.WriteString(indent(fmt.Sprintf("%+v", stack), "    "))
+		}
+
+		switch e := err.(type) {
+		case *truncated:
+			// causes repeat messages, which were truncated
+			return
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			children := e.Unwrap()
+			for i, child := range children {
+				buf.WriteString("--- caused by [" + strconv.Itoa(i+1) + "/" + strconv.Itoa(len(children)) + "] ---\n")
+				var sub bytes.Buffer
+				formatChain(&sub, child)
+				buf.WriteString(indent(sub.String(), "    "))
+			}
+			return
+		default:
+			return
+		}
+	}
+}
+
+// indent prefixes each non-empty line of s with prefix.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if line != "" && line != "\n" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,57 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestFormatChain(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{{
+		name: "nil",
+		err:  nil,
+		want: "",
+	}, {
+		name: "foreign",
+		err:  io.EOF,
+		want: `\*errors.errorString: EOF`,
+	}, {
+		name: "chain",
+		err:  errors.WithField(errors.Wrap(errors.New("not found"), "read"), "user_id", 42),
+		want: `\*errors.withField\n` +
+			`    fields: user_id=42\n` +
+			`--- caused by ---\n` +
+			`\*errors.withMessage: read\n` +
+			`    at ` + errors.PkgName + `.TestFormatChain\n` +
+			`    \t.+/chain_test.go:\d+\n` +
+			`--- caused by ---\n` +
+			`\*errors.fundamental: not found\n` +
+			`    ` + errors.PkgName + `.TestFormatChain\n` +
+			`    \t.+/chain_test.go:\d+\n` +
+			`(?s:.*)`,
+	}, {
+		name: "joined",
+		err:  errors.Join(io.EOF, errors.WithMessage(io.ErrUnexpectedEOF, "read")),
+		want: `\*errors.joined: EOF\nread: unexpected EOF\n` +
+			`--- caused by \[1/2\] ---\n` +
+			`    \*errors.errorString: EOF\n` +
+			`--- caused by \[2/2\] ---\n` +
+			`    \*errors.withMessage: read\n` +
+			`    --- caused by ---\n` +
+			`    \*errors.errorString: unexpected EOF`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errors.FormatChain(tt.err)
+			if !regexpMatch(tt.want, got) {
+				t.Errorf("FormatChain:\ngot:  %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
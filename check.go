@@ -0,0 +1,81 @@
+package errors
+
+import "fmt"
+
+// checked is a panic value used by Check to pass the error to Handle. It's
+// an error itself, so panics, which are not handled, are printed with
+// original message, and recoverers can print its stack trace with %+v.
+type checked struct {
+	err error
+}
+
+func (c checked) Error() string { return c.err.Error() }
+func (c checked) Unwrap() error { return c.err }
+
+func (c checked) Format(s fmt.State, verb rune) { formatTransparent(s, verb, c.err) }
+
+// Check panics, if err is not nil. Panic value carries err with a stack trace
+// at the point Check was called (if err doesn't have one yet), and can be
+// converted back into an error by Handle deferred at function boundary:
+//
+//	func parse(r io.Reader) (err error) {
+//	    defer errors.Handle(&err)
+//
+//	    errors.Check(readHeader(r))
+//	    errors.Check(readBody(r))
+//	    return nil
+//	}
+//
+// Check must not be used without Handle, and panics must not leak through
+// package API.
+func Check(err error) { check(err, 1) }
+
+func check(err error, extraSkip uint) {
+	if err == nil {
+		return
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1+extraSkip)
+	}
+	panic(checked{err: err})
+}
+
+// Handle recovers panic caused by Check and stores its error in errp. Any
+// other panic is propagated as is. Handle must be called directly by defer
+// statement.
+//
+// If msgAndArgs are provided, Handle also annotates non-nil *errp (either
+// returned or recovered) with message, the same way as Wrapf does. First
+// element of msgAndArgs is a format specifier and the rest ones are its
+// arguments. It removes Wrap boilerplate at every return statement of long
+// functions with named error result:
+//
+//	func loadUser(id int) (u *User, err error) {
+//	    defer errors.Handle(&err, "loading user %d", id)
+//	    ...
+//	}
+func Handle(errp *error, msgAndArgs ...interface{}) {
+	if r := recover(); r != nil {
+		c, ok := r.(checked)
+		if !ok {
+			panic(r)
+		}
+		*errp = c.err
+		if len(msgAndArgs) > 0 {
+			// callers of panicking goroutine are runtime functions, so wrap
+			// point is not recorded
+			*errp = created(&withMessage{cause: c.err, msg: handleMessage(msgAndArgs)})
+		}
+		return
+	}
+	if *errp != nil && len(msgAndArgs) > 0 {
+		*errp = wrap(*errp, handleMessage(msgAndArgs), 1)
+	}
+}
+
+func handleMessage(msgAndArgs []interface{}) string {
+	if format, ok := msgAndArgs[0].(string); ok {
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return fmt.Sprint(msgAndArgs...)
+}
@@ -0,0 +1,23 @@
+//go:build go1.18
+
+package errors
+
+// Must returns v, if err is nil, otherwise it panics the same way as Check
+// does. It's intended for initialization code and tests:
+//
+//	var tmpl = errors.Must(template.ParseFiles("index.html"))
+//
+// Panic value is an error with stack trace at the point Must was called (if
+// err doesn't have one yet), so recoverers can print it with %+v. Inside of
+// functions with deferred Handle, Must behaves exactly like Check.
+func Must[T any](v T, err error) T {
+	check(err, 1)
+	return v
+}
+
+// Must2 is like Must, but for functions, which return two values and an
+// error.
+func Must2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	check(err, 1)
+	return v1, v2
+}
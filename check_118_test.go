@@ -0,0 +1,53 @@
+//go:build go1.18
+
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestMust(t *testing.T) {
+	require.Equal(t, 42, errors.Must(strconv.Atoi("42")))
+
+	a, b := errors.Must2(1, "b", nil)
+	require.Equal(t, 1, a)
+	require.Equal(t, "b", b)
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		require.True(t, ok)
+		require.EqualError(t, err, "EOF")
+		require.True(t, errors.Is(err, io.EOF))
+		require.Equal(t, errors.PkgName+".TestMust", funcName(errors.Stack(err)[0]))
+		require.Regexp(t, `^EOF\n`+errors.PkgName+`\.TestMust\n`, fmt.Sprintf("%+v", err))
+	}()
+	errors.Must2(1, 2, io.EOF)
+}
+
+func mustRead(fail bool) (n int, err error) {
+	defer errors.Handle(&err)
+
+	var readErr error
+	if fail {
+		readErr = io.EOF
+	}
+	return errors.Must(42, readErr), nil
+}
+
+func TestMustHandle(t *testing.T) {
+	n, err := mustRead(false)
+	require.NoError(t, err)
+	require.Equal(t, 42, n)
+
+	_, err = mustRead(true)
+	require.Equal(t, io.EOF, errors.Cause(err))
+	require.Regexp(t, `mustRead$`, funcName(errors.Stack(err)[0]))
+}
@@ -0,0 +1,82 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func checkedRead(fail bool) (err error) {
+	defer errors.Handle(&err)
+
+	errors.Check(nil)
+	if fail {
+		errors.Check(io.EOF)
+	}
+	return nil
+}
+
+func TestCheck(t *testing.T) {
+	require.NoError(t, checkedRead(false))
+
+	err := checkedRead(true)
+	require.True(t, stderrors.Is(err, io.EOF))
+	require.NotNil(t, errors.Stack(err))
+	require.Regexp(t, `checkedRead$`, funcName(errors.Stack(err)[0]))
+}
+
+func TestHandleForeignPanic(t *testing.T) {
+	require.PanicsWithValue(t, "boom", func() {
+		var err error
+		defer errors.Handle(&err)
+		panic("boom")
+	})
+}
+
+func loadUser(id int, err error) (_ string, resErr error) {
+	defer errors.Handle(&resErr, "loading user %d", id)
+
+	if err != nil {
+		return "", err
+	}
+	return "alice", nil
+}
+
+func checkedLoadUser(id int, err error) (_ string, resErr error) {
+	defer errors.Handle(&resErr, "loading user %d", id)
+
+	errors.Check(err)
+	return "alice", nil
+}
+
+func TestHandleMessage(t *testing.T) {
+	name, err := loadUser(42, nil)
+	require.NoError(t, err)
+	require.Equal(t, "alice", name)
+
+	_, err = loadUser(42, io.EOF)
+	require.EqualError(t, err, "loading user 42: EOF")
+	require.True(t, stderrors.Is(err, io.EOF))
+	require.Regexp(t, `loadUser$`, funcName(errors.Stack(err)[0]))
+
+	origin := errors.New("origin")
+	_, err = loadUser(42, origin)
+	require.EqualError(t, err, "loading user 42: origin")
+	require.Equal(t, errors.Stack(origin), errors.Stack(err))
+
+	_, err = checkedLoadUser(42, io.EOF)
+	require.EqualError(t, err, "loading user 42: EOF")
+	require.Regexp(t, `checkedLoadUser$`, funcName(errors.Stack(err)[0]))
+
+	_, err = checkedLoadUser(42, nil)
+	require.NoError(t, err)
+}
+
+func funcName(f errors.Frame) string {
+	_, _, name := f.FuncInfo()
+	return name
+}
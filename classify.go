@@ -0,0 +1,43 @@
+package errors
+
+import "context"
+
+// IsTimeout reports whether any error in err's tree is a timeout:
+// context.DeadlineExceeded, net.Error, os.ErrDeadlineExceeded or any other
+// error, which implements Timeout() bool method, returning true.
+func IsTimeout(err error) bool {
+	res := false
+	walkTree(err, func(e error) {
+		if t, ok := e.(interface{ Timeout() bool }); ok && t.Timeout() {
+			res = true
+		}
+	})
+	return res
+}
+
+// IsCanceled reports whether any error in err's tree is context.Canceled.
+func IsCanceled(err error) bool { return Is(err, context.Canceled) }
+
+// IsTemporary reports whether err is a temporary failure, so operation can be
+// retried. Decision is made by the outermost error in err's tree, which
+// either is marked with this package's retry markers (see Transient and
+// Permanent) or implements Retryable() bool or Temporary() bool methods (like
+// net.Error), or is a timeout (see IsTimeout). If there is no such error,
+// IsTemporary returns false.
+func IsTemporary(err error) bool {
+	decided, res := false, false
+	walkTree(err, func(e error) {
+		if decided {
+			return
+		}
+		switch t := e.(type) {
+		case interface{ Retryable() bool }:
+			decided, res = true, t.Retryable()
+		case interface{ Temporary() bool }:
+			decided, res = true, t.Temporary()
+		case interface{ Timeout() bool }:
+			decided, res = t.Timeout(), t.Timeout()
+		}
+	})
+	return res
+}
@@ -0,0 +1,48 @@
+package errors_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type temporaryError struct{ temporary, timeout bool }
+
+func (e *temporaryError) Error() string   { return "temporary" }
+func (e *temporaryError) Temporary() bool { return e.temporary }
+func (e *temporaryError) Timeout() bool   { return e.timeout }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantTimeout   bool
+		wantCanceled  bool
+		wantTemporary bool
+	}{
+		{"nil", nil, false, false, false},
+		{"plain", io.EOF, false, false, false},
+		{"deadline", errors.Wrap(context.DeadlineExceeded, "call"), true, false, true},
+		{"canceled", errors.Wrap(context.Canceled, "call"), false, true, false},
+		{"os deadline", errors.Wrap(os.ErrDeadlineExceeded, "read"), true, false, true},
+		{"net timeout", &net.OpError{Op: "dial", Err: &temporaryError{timeout: true, temporary: true}}, true, false, true},
+		{"net temporary", &net.OpError{Op: "dial", Err: &temporaryError{temporary: true}}, false, false, true},
+		{"transient", errors.Transient(io.EOF), false, false, true},
+		{"permanent timeout", errors.Permanent(context.DeadlineExceeded), true, false, false},
+		{"joined", errors.Join(io.EOF, context.Canceled), false, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantTimeout, errors.IsTimeout(tt.err), "timeout")
+			require.Equal(t, tt.wantCanceled, errors.IsCanceled(tt.err), "canceled")
+			require.Equal(t, tt.wantTemporary, errors.IsTemporary(tt.err), "temporary")
+		})
+	}
+}
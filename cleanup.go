@@ -0,0 +1,74 @@
+package errors
+
+import "io"
+
+// AppendInto appends err to the error pointed by errp: if *errp is nil, err
+// is stored as is, otherwise both errors are joined (see Join), so none of
+// them is dropped. If err doesn't have a stack trace, AppendInto also records
+// the stack trace at the point it was called. AppendInto reports whether err
+// was non-nil.
+func AppendInto(errp *error, err error) bool {
+	if err == nil {
+		return false
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	appendInto(errp, err)
+	return true
+}
+
+// AppendInvoke calls fn and appends its error to the error pointed by errp,
+// annotated with message (see Wrap), if it's not empty. It's intended for
+// deferred cleanups, whose errors would be lost otherwise:
+//
+//	defer errors.AppendInvoke(&err, tx.Rollback, "rolling back")
+func AppendInvoke(errp *error, fn func() error, message string) {
+	if err := fn(); err != nil {
+		appendInto(errp, cleanupError(err, message, 1))
+	}
+}
+
+// CloseAndAppend closes c and appends its error to the error pointed by errp,
+// annotated with message, see AppendInvoke:
+//
+//	func load(path string) (err error) {
+//		f, err := os.Open(path)
+//		if err != nil {
+//			return errors.WithStack(err)
+//		}
+//		defer errors.CloseAndAppend(&err, f, "closing file")
+//		...
+//	}
+//
+// If both the main error and error of Close are non-nil, they're joined with
+// their stack traces instead of dropping one of them.
+func CloseAndAppend(errp *error, c io.Closer, message string) {
+	if err := c.Close(); err != nil {
+		appendInto(errp, cleanupError(err, message, 1))
+	}
+}
+
+func cleanupError(err error, message string, extraSkip uint) error {
+	if message == "" {
+		if Stack(err) != nil {
+			return err
+		}
+		return wStack(err, 1+extraSkip)
+	}
+	return wrap(err, message, 1+extraSkip)
+}
+
+// appendInto joins err with *errp. If *errp is already joined, err is added
+// to its children, so repeated cleanups produce a flat list.
+func appendInto(errp *error, err error) {
+	switch prev := (*errp).(type) {
+	case nil:
+		*errp = err
+	case *joined:
+		errs := make([]error, 0, len(prev.errs)+1)
+		*errp = &joined{errs: append(append(errs, prev.errs...), err)}
+	default:
+		*errp = Join(prev, err)
+	}
+}
@@ -0,0 +1,66 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func closeWith(closeErr, mainErr error) (err error) {
+	defer errors.CloseAndAppend(&err, closerFunc(func() error { return closeErr }), "closing db")
+	return mainErr
+}
+
+func TestCloseAndAppend(t *testing.T) {
+	require.NoError(t, closeWith(nil, nil))
+
+	mainErr := errors.New("query failed")
+	require.Same(t, mainErr, closeWith(nil, mainErr))
+
+	err := closeWith(io.ErrClosedPipe, nil)
+	require.EqualError(t, err, "closing db: io: read/write on closed pipe")
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+	require.NotEmpty(t, errors.Stack(err))
+	require.Equal(t, errors.PkgName+".closeWith", funcName(errors.Stack(err)[0]))
+
+	err = closeWith(io.ErrClosedPipe, mainErr)
+	require.ErrorIs(t, err, mainErr)
+	require.ErrorIs(t, err, io.ErrClosedPipe)
+	require.Len(t, errors.Joined(err), 2)
+	require.Len(t, errors.StackAll(err), 2)
+}
+
+func TestAppendInvoke(t *testing.T) {
+	var err error
+	errors.AppendInvoke(&err, func() error { return nil }, "rolling back")
+	require.NoError(t, err)
+
+	errors.AppendInvoke(&err, func() error { return io.EOF }, "")
+	require.ErrorIs(t, err, io.EOF)
+	require.NotEmpty(t, errors.Stack(err))
+
+	errors.AppendInvoke(&err, func() error { return io.ErrUnexpectedEOF }, "rolling back")
+	errors.AppendInvoke(&err, func() error { return io.ErrShortWrite }, "closing")
+	require.Len(t, errors.Joined(err), 3, "appended errors must be kept flat")
+	require.EqualError(t, errors.Joined(err)[2], "closing: short write")
+}
+
+func TestAppendInto(t *testing.T) {
+	var err error
+	require.False(t, errors.AppendInto(&err, nil))
+	require.NoError(t, err)
+
+	require.True(t, errors.AppendInto(&err, io.EOF))
+	require.ErrorIs(t, err, io.EOF)
+	require.NotEmpty(t, errors.Stack(err))
+
+	require.True(t, errors.AppendInto(&err, io.ErrUnexpectedEOF))
+	require.Len(t, errors.Joined(err), 2)
+}
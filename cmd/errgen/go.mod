@@ -0,0 +1,14 @@
+module github.com/quenbyako/errors/cmd/errgen
+
+go 1.20
+
+require (
+	github.com/quenbyako/errors v0.0.0-20261016165627-c6d3347d4d0a
+	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
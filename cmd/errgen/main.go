@@ -0,0 +1,61 @@
+// Command errgen generates typed error catalog from YAML file. See package
+// github.com/quenbyako/errors/errgen for format of the catalog.
+//
+// Usage:
+//
+//	errgen -in errors.yaml -out errors_gen.go
+//
+// It's intended to be used with go generate:
+//
+//	//go:generate go run github.com/quenbyako/errors/cmd/errgen -in errors.yaml -out errors_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/errgen"
+)
+
+func main() {
+	in := flag.String("in", "errors.yaml", "path to catalog file")
+	out := flag.String("out", "", "path to generated file (default stdout)")
+	pkg := flag.String("pkg", "", "package name of generated file (overrides catalog)")
+	flag.Parse()
+
+	if err := run(*in, *out, *pkg); err != nil {
+		fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, pkg string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var c errgen.Catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return errors.Wrapf(err, "parsing %v", in)
+	}
+	if pkg != "" {
+		c.Package = pkg
+	}
+
+	var buf bytes.Buffer
+	if err := errgen.Generate(&buf, &c); err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.WriteFile(out, buf.Bytes(), 0o644))
+}
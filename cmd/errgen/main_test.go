@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors/errtest"
+)
+
+func TestRunGolden(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "errors_gen.go")
+	require.NoError(t, run(filepath.Join("testdata", "errors.yaml"), out, ""))
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "errors_gen.go.golden")
+	if os.Getenv(errtest.UpdateEnv) != "" {
+		require.NoError(t, os.WriteFile(golden, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err, "run with %s=1 to create golden file", errtest.UpdateEnv)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestRunPackage(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "errors_gen.go")
+	require.NoError(t, run(filepath.Join("testdata", "errors.yaml"), out, "accounts"))
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(got), "\npackage accounts\n")
+}
+
+func TestRunInvalid(t *testing.T) {
+	require.Error(t, run(filepath.Join("testdata", "missing.yaml"), "", ""))
+
+	in := filepath.Join(t.TempDir(), "errors.yaml")
+	require.NoError(t, os.WriteFile(in, []byte("errors: ["), 0o644))
+	err := run(in, "", "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parsing "+in)
+}
@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"fmt"
+)
+
+type withCode struct {
+	cause error
+	code  int
+}
+
+// WithCode annotates err with a numeric error code, which can be extracted
+// later with Code.
+// If err is nil, WithCode returns nil.
+func WithCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &withCode{cause: err, code: code}
+}
+
+func (w *withCode) Error() string   { return w.cause.Error() }
+func (w *withCode) Unwrap() error   { return w.cause }
+func (w *withCode) message() string { return "" }
+
+func (w *withCode) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withCode) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Code returns the outermost error code in err's chain. If there is no code,
+// Code returns false.
+func Code(err error) (int, bool) {
+	for ; err != nil; err = Unwrap(err) {
+		if c, ok := err.(*withCode); ok {
+			return c.code, true
+		}
+	}
+	return 0, false
+}
+
+// IsCode reports whether the outermost error code in err's chain equals code.
+func IsCode(err error, code int) bool {
+	c, ok := Code(err)
+	return ok && c == code
+}
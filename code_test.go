@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestWithCodeNil(t *testing.T) {
+	require.Nil(t, errors.WithCode(nil, 42))
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   int
+		wantOk bool
+	}{
+		{"nil", nil, 0, false},
+		{"no code", errors.Wrap(io.EOF, "read"), 0, false},
+		{"code", errors.WithCode(io.EOF, 42), 42, true},
+		{"wrapped", errors.Wrap(errors.WithCode(io.EOF, 42), "read"), 42, true},
+		{"outer wins", errors.WithCode(errors.WithCode(io.EOF, 42), 43), 43, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := errors.Code(tt.err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.wantOk, errors.IsCode(tt.err, tt.want))
+		})
+	}
+}
+
+func TestCodeRemapper(t *testing.T) {
+	errNotFound := errors.New("not found")
+	remappers := []errors.ErrRemapperFunc{
+		errors.CodeRemapper(404, errNotFound),
+	}
+
+	require.Equal(t, errNotFound, errors.Remap(errors.Wrap(errors.WithCode(io.EOF, 404), "get"), remappers))
+	require.Equal(t, io.EOF, errors.Remap(io.EOF, remappers))
+}
+
+func TestCodeSerialization(t *testing.T) {
+	err := errors.Sanitize(errors.WithCode(io.EOF, 404), errors.SanitizePolicy{KeepCode: true})
+	require.True(t, errors.IsCode(err, 404))
+
+	b, err := errors.ToJSON(err)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"message": "internal error", "code": 404, "cause": {"message": "internal error"}}`, string(b))
+
+	got, err := errors.FromJSON(b)
+	require.NoError(t, err)
+	require.True(t, errors.IsCode(got, 404))
+}
@@ -0,0 +1,63 @@
+package errors
+
+import "context"
+
+// Collector accumulates non-fatal errors (e.g. partial failures), which
+// happen during a single request or operation, so they can be reported
+// together at the end. Collector is bound to context by NewCollector, and
+// errors are added with Collect by any code, which has the context.
+// Collector is safe for concurrent use.
+type Collector struct {
+	g *Group
+}
+
+type collectorKey struct{}
+
+// NewCollector returns context derived from ctx, which carries a new
+// Collector, and the collector itself:
+//
+//	ctx, c := errors.NewCollector(r.Context())
+//	serve(ctx)
+//	if err := c.Drain(); err != nil {
+//		log.Printf("request finished with issues: %+v", err)
+//	}
+func NewCollector(ctx context.Context) (context.Context, *Collector) {
+	c := &Collector{g: NewGroup(WithLock())}
+	return context.WithValue(ctx, collectorKey{}, c), c
+}
+
+// Collect adds err to the Collector of ctx (see NewCollector). If err
+// doesn't have a stack trace, Collect also records the stack trace at the
+// point it was called. Collect reports whether ctx carries a collector,
+// otherwise err is discarded. If err is nil, Collect does nothing.
+func Collect(ctx context.Context, err error) bool {
+	c, ok := ctx.Value(collectorKey{}).(*Collector)
+	if !ok {
+		return false
+	}
+	if err == nil {
+		return true
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	c.g.Add(err)
+	return true
+}
+
+// Len returns number of collected errors.
+func (c *Collector) Len() int { return c.g.Len() }
+
+// Drain returns collected errors as a single error (see Group.Err) and
+// resets the collector. If there are no errors, Drain returns nil.
+func (c *Collector) Drain() error {
+	c.g.lock()
+	errs := c.g.errs
+	c.g.errs = nil
+	c.g.unlock()
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return Join(errs...)
+}
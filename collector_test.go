@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestCollector(t *testing.T) {
+	require.False(t, errors.Collect(context.Background(), io.EOF))
+
+	ctx, c := errors.NewCollector(context.Background())
+	require.NoError(t, c.Drain())
+
+	require.True(t, errors.Collect(ctx, nil))
+	require.True(t, errors.Collect(ctx, io.EOF))
+	require.Equal(t, 1, c.Len())
+
+	err := c.Drain()
+	require.Equal(t, io.EOF, errors.Cause(err))
+	require.Equal(t, errors.PkgName+".TestCollector", funcName(errors.Stack(err)[0]))
+	require.Zero(t, c.Len())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errors.Collect(ctx, errors.New("partial failure"))
+		}()
+	}
+	wg.Wait()
+
+	err = c.Drain()
+	require.Len(t, errors.Joined(err), 4)
+	require.NoError(t, c.Drain())
+}
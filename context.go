@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextExtractor extracts structured fields (e.g. trace or request ID)
+// from context, which must be attached to errors by Wrapc and
+// WithContextValues.
+type ContextExtractor func(ctx context.Context) []Field
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   []ContextExtractor
+)
+
+// RegisterContextExtractor registers extractor of context values. It's
+// intended to be called from init functions of packages, which put values
+// into context:
+//
+//	func init() {
+//		errors.RegisterContextExtractor(func(ctx context.Context) []errors.Field {
+//			if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+//				return []errors.Field{{Key: "request_id", Value: id}}
+//			}
+//			return nil
+//		})
+//	}
+func RegisterContextExtractor(e ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors = append(extractors, e)
+}
+
+// WithContextValues annotates err with fields, extracted from ctx by all
+// registered extractors (see RegisterContextExtractor).
+// If err is nil, WithContextValues returns nil.
+func WithContextValues(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	extractorsMu.RLock()
+	list := extractors
+	extractorsMu.RUnlock()
+
+	var fields []Field
+	for _, extract := range list {
+		fields = append(fields, extract(ctx)...)
+	}
+	for i := len(fields) - 1; i >= 0; i-- {
+		err = &withField{cause: err, field: fields[i]}
+	}
+	return err
+}
+
+// Wrapc returns an error annotating err with fields, extracted from ctx (see
+// WithContextValues), and with the supplied message, the same way as Wrap
+// does.
+// If err is nil, Wrapc returns nil.
+func Wrapc(ctx context.Context, err error, message string) error {
+	return wrap(WithContextValues(ctx, err), message, 1)
+}
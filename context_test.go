@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type requestIDKey struct{}
+
+func init() {
+	errors.RegisterContextExtractor(func(ctx context.Context) []errors.Field {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			return []errors.Field{{Key: "request_id", Value: id}}
+		}
+		return nil
+	})
+}
+
+func TestWrapc(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+
+	err := errors.Wrapc(ctx, io.EOF, "read")
+	require.EqualError(t, err, "read: EOF")
+	require.True(t, errors.Is(err, io.EOF))
+	require.Equal(t, map[string]interface{}{"request_id": "abc"}, errors.Fields(err))
+	require.Equal(t, errors.PkgName+".TestWrapc", funcName(errors.Stack(err)[0]))
+
+	err = errors.Wrapc(context.Background(), io.EOF, "read")
+	require.Empty(t, errors.Fields(err))
+
+	require.Nil(t, errors.Wrapc(ctx, nil, "read"))
+}
+
+func TestWithContextValues(t *testing.T) {
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+
+	err := errors.WithContextValues(ctx, io.EOF)
+	require.Equal(t, io.EOF.Error(), err.Error())
+	require.Equal(t, map[string]interface{}{"request_id": "abc"}, errors.Fields(err))
+
+	require.Nil(t, errors.WithContextValues(ctx, nil))
+}
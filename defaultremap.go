@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type namedRule struct {
+	name string
+	rule ErrRemapperFunc
+}
+
+var (
+	defaultRulesMu sync.Mutex
+	defaultRules   atomic.Value // []namedRule
+)
+
+// RegisterRemapper registers rule of default remapper, which is applied by
+// RemapDefault. It's intended to be called from init functions of feature
+// packages, so they can register translations of their own errors, and
+// transport layer applies them uniformly:
+//
+//	func init() {
+//		errors.RegisterRemapper("users.not_found", errors.IsRemapper(sql.ErrNoRows, ErrUserNotFound))
+//	}
+//
+// Rules are checked in order of registration. If rule with the same name is
+// already registered, it's replaced in place. Nil rule unregisters rule with
+// the name. RegisterRemapper is safe for concurrent use.
+func RegisterRemapper(name string, rule ErrRemapperFunc) {
+	defaultRulesMu.Lock()
+	defer defaultRulesMu.Unlock()
+
+	old, _ := defaultRules.Load().([]namedRule)
+	res := make([]namedRule, 0, len(old)+1)
+	replaced := false
+	for _, r := range old {
+		if r.name != name {
+			res = append(res, r)
+			continue
+		}
+		if rule != nil {
+			res = append(res, namedRule{name: name, rule: rule})
+		}
+		replaced = true
+	}
+	if !replaced && rule != nil {
+		res = append(res, namedRule{name: name, rule: rule})
+	}
+	defaultRules.Store(res)
+}
+
+// RemapDefault remaps err with rules, registered by RegisterRemapper, in the
+// same way, as Remapper.Remap does: each rule is checked against every error
+// in the chain, first matched rule wins. If no rule matched, err is returned
+// as is. RemapDefault is safe for concurrent use.
+func RemapDefault(err error) error {
+	if err == nil {
+		return nil
+	}
+	rules, _ := defaultRules.Load().([]namedRule)
+	for _, r := range rules {
+		if e, ok := remapChain(err, r.rule); ok {
+			return e
+		}
+	}
+	return err
+}
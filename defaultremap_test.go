@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestRemapDefault(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errClosed := errors.New("closed")
+
+	errors.RegisterRemapper("test.eof", errors.IsRemapper(io.EOF, errNotFound))
+	errors.RegisterRemapper("test.closed", errors.IsRemapper(io.ErrClosedPipe, errClosed))
+	defer errors.RegisterRemapper("test.eof", nil)
+	defer errors.RegisterRemapper("test.closed", nil)
+
+	require.Nil(t, errors.RemapDefault(nil))
+	require.Equal(t, errNotFound, errors.RemapDefault(errors.Wrap(io.EOF, "read")))
+	require.Equal(t, errClosed, errors.RemapDefault(io.ErrClosedPipe))
+	require.Equal(t, io.ErrUnexpectedEOF, errors.RemapDefault(io.ErrUnexpectedEOF))
+
+	errors.RegisterRemapper("test.eof", errors.IsRemapper(io.EOF, errClosed))
+	require.Equal(t, errClosed, errors.RemapDefault(io.EOF))
+
+	errors.RegisterRemapper("test.eof", nil)
+	require.Equal(t, io.EOF, errors.RemapDefault(io.EOF))
+}
+
+func TestRemapDefaultConcurrent(t *testing.T) {
+	defer errors.RegisterRemapper("test.concurrent", nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errors.RegisterRemapper("test.concurrent", errors.IsRemapper(io.EOF, io.ErrUnexpectedEOF))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = errors.RemapDefault(io.EOF)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, io.ErrUnexpectedEOF, errors.RemapDefault(io.EOF))
+}
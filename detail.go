@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DetailFormatter can be implemented by foreign error types, which are placed
+// in the middle of error chain, to contribute their own details (extra lines,
+// own frames, etc.) into extended %+v format of this package's errors,
+// instead of being flattened to a bare message line.
+//
+// Details are printed on the next line after error message. Trailing newline
+// is optional.
+type DetailFormatter interface {
+	error
+	FormatDetail(w io.Writer)
+}
+
+// formatDetailed writes err into s in extended format: errors implementing
+// fmt.Formatter are formatted with %+v verb (or %#+v, if s is a fmt.State
+// with '#' flag), message of DetailFormatter is followed by its details.
+// Foreign wrappers, which end with message of their cause (like ones created
+// by fmt.Errorf with %w verb), are printed with details of the cause.
+func formatDetailed(s io.Writer, err error) { formatDetailedVerb(s, err, detailVerb(s)) }
+
+// detailVerb returns format of nested errors, keeping '#' flag of s, if s is
+// a fmt.State.
+func detailVerb(s io.Writer) string {
+	if st, ok := s.(fmt.State); ok && st.Flag('#') {
+		return "%#+v"
+	}
+	return "%+v"
+}
+
+func formatDetailedVerb(s io.Writer, err error, verb string) {
+	switch e := err.(type) {
+	case fmt.Formatter:
+		// space flag marks nested error, see formatFooter
+		fmt.Fprintf(s, "% "+verb[1:], e)
+	case DetailFormatter:
+		io.WriteString(s, e.Error())
+		var buf bytes.Buffer
+		e.FormatDetail(&buf)
+		if detail := bytes.TrimRight(buf.Bytes(), "\n"); len(detail) > 0 {
+			io.WriteString(s, "\n")
+			s.Write(detail)
+		}
+	case interface {
+		error
+		Unwrap() error
+	}:
+		// stdlib wrappers, e.g. created by fmt.Errorf with %w verb, would
+		// hide details of their causes otherwise
+		msg, cause := e.Error(), e.Unwrap()
+		if cause == nil || Stack(cause) == nil || !strings.HasSuffix(msg, cause.Error()) {
+			io.WriteString(s, msg)
+			return
+		}
+		io.WriteString(s, strings.TrimSuffix(msg, cause.Error()))
+		formatDetailedVerb(s, cause, verb)
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// formatTransparent formats wrapper of err, which doesn't change its
+// message (e.g. only attaches some data).
+func formatTransparent(s fmt.State, verb rune, err error) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatDetailed(s, err)
+			formatFooter(s, err)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, err.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", err.Error())
+	}
+}
+
+// formatFooter writes footer of extended format of err (build info, see
+// WithBuildInfo), if s formats the outermost error: errors, nested into
+// other ones, are formatted with space flag by formatDetailedVerb, so footer
+// is printed only once, at the very end of output.
+func formatFooter(s fmt.State, err error) {
+	if s.Flag(' ') {
+		return
+	}
+	if info, ok := footerBuildInfo(err); ok {
+		io.WriteString(s, "\nbuild: "+info.String())
+	}
+}
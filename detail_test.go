@@ -0,0 +1,56 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/quenbyako/errors"
+)
+
+type detailedErr struct{}
+
+func (detailedErr) Error() string { return "query failed" }
+
+func (detailedErr) FormatDetail(w io.Writer) {
+	io.WriteString(w, "\tSQLSTATE 23505\n\tconstraint users_email_key\n")
+}
+
+func TestDetailFormatter(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{{
+		errors.WithMessage(detailedErr{}, "insert user"),
+		"insert user: query failed\n" +
+			"\tSQLSTATE 23505\n" +
+			"\tconstraint users_email_key",
+	}, {
+		errors.Wrap(detailedErr{}, "insert user"),
+		"insert user: query failed\n" +
+			"\tSQLSTATE 23505\n" +
+			"\tconstraint users_email_key\n" +
+			errors.PkgName + ".TestDetailFormatter\n" +
+			"\t.+/" + errors.PkgNameRaw + "/detail_test.go:\\d+\n" +
+			"(?s).*",
+	}, {
+		errors.Opaque(errors.WithMessage(detailedErr{}, "insert user")),
+		"insert user: query failed\n" +
+			"\tSQLSTATE 23505\n" +
+			"\tconstraint users_email_key",
+	}}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			got := fmt.Sprintf("%+v", tt.err)
+			if !regexpMatch(tt.want, got) {
+				t.Errorf("%%+v:\ngot:  %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func regexpMatch(want, got string) bool {
+	return regexp.MustCompile("^" + want + "$").MatchString(got)
+}
@@ -0,0 +1,128 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// diffLayer is a single error of the chain, prepared for comparison.
+type diffLayer struct {
+	desc string
+	// branches are chains of errors, aggregated by multi-error.
+	branches [][]diffLayer
+}
+
+// Diff compares chains of want and got structurally: types, own messages,
+// codes (including HTTP statuses, kinds and severities) and fields of their
+// errors, layer by layer. Stack traces are ignored, as well as wrappers,
+// which only attach stack traces, timestamps, goroutine labels or build
+// info, so chains built by Wrap and WithMessage with different stack
+// presence are still equal. Diff returns empty string, if chains are equal,
+// otherwise every differing layer is described:
+//
+//	layer 2:
+//		want: *errors.withMessage: read config
+//		got:  *errors.withMessage: load config
+//
+// Layers of multi-errors' branches are numbered by path, e.g. "layer 3.1.2"
+// is the second layer of the first branch of the third layer.
+func Diff(want, got error) string {
+	var b strings.Builder
+	diffChains(&b, "", diffLayers(want), diffLayers(got))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// EqualChains reports whether chains of a and b are structurally equal, see
+// Diff.
+func EqualChains(a, b error) bool { return Diff(a, b) == "" }
+
+func diffChains(b *strings.Builder, prefix string, want, got []diffLayer) {
+	n := len(want)
+	if len(got) > n {
+		n = len(got)
+	}
+	for i := 0; i < n; i++ {
+		name := prefix + strconv.Itoa(i+1)
+		var w, g *diffLayer
+		if i < len(want) {
+			w = &want[i]
+		}
+		if i < len(got) {
+			g = &got[i]
+		}
+		if w == nil || g == nil || w.desc != g.desc || len(w.branches) != len(g.branches) {
+			b.WriteString("layer " + name + ":\n")
+			b.WriteString("\twant: " + w.String() + "\n")
+			b.WriteString("\tgot:  " + g.String() + "\n")
+			continue
+		}
+		for j := range w.branches {
+			diffChains(b, name+"."+strconv.Itoa(j+1)+".", w.branches[j], g.branches[j])
+		}
+	}
+}
+
+func (l *diffLayer) String() string {
+	if l == nil {
+		return "<none>"
+	}
+	if len(l.branches) > 0 {
+		return l.desc + " (" + strconv.Itoa(len(l.branches)) + " branches)"
+	}
+	return l.desc
+}
+
+func diffLayers(err error) []diffLayer {
+	var res []diffLayer
+	for err != nil {
+		switch err.(type) {
+		case *withStack, *withTime, *withLabels, *withBuildInfo, *remapTraced, *adopted:
+			// environment specific data and transparent adapters, which are
+			// ignored
+		default:
+			res = append(res, diffLayer{desc: diffDesc(err)})
+		}
+
+		switch e := err.(type) {
+		case *truncated:
+			err = nil
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			l := &res[len(res)-1]
+			for _, child := range e.Unwrap() {
+				l.branches = append(l.branches, diffLayers(child))
+			}
+			err = nil
+		default:
+			err = nil
+		}
+	}
+	return res
+}
+
+// diffDesc describes err itself (without its causes) for comparison.
+func diffDesc(err error) string {
+	desc := fmt.Sprintf("%T", err)
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		// message of multi-error consists of messages of its children
+		if msg := ownMessage(err); msg != "" {
+			desc += ": " + msg
+		}
+	}
+	switch e := err.(type) {
+	case *withCode:
+		desc += " [code=" + strconv.Itoa(e.code) + "]"
+	case *withHTTPStatus:
+		desc += " [status=" + strconv.Itoa(e.status) + "]"
+	case *withKind:
+		desc += " [kind=" + e.kind.String() + "]"
+	case *withSeverity:
+		desc += " [severity=" + e.level.String() + "]"
+	}
+	if f, ok := err.(interface{ fields() []Field }); ok && len(f.fields()) > 0 {
+		desc += " {" + formatFields(f.fields()) + "}"
+	}
+	return desc
+}
@@ -0,0 +1,85 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestDiffEqual(t *testing.T) {
+	require.Empty(t, errors.Diff(nil, nil))
+	require.True(t, errors.EqualChains(nil, nil))
+
+	// stack traces and wrappers, which only record them, are ignored
+	want := errors.Wrap(errors.NoStack("file not found"), "read config")
+	got := errors.Wrap(errors.New("file not found"), "read config")
+	require.Empty(t, errors.Diff(want, got))
+	require.True(t, errors.EqualChains(want, got))
+
+	build := func() error {
+		return errors.WithCode(errors.WithField(errors.Join(io.EOF, errors.New("boom")), "id", 42), 7)
+	}
+	require.True(t, errors.EqualChains(build(), build()))
+}
+
+func TestDiffMessage(t *testing.T) {
+	want := errors.Wrap(errors.New("file not found"), "read config")
+	got := errors.Wrap(errors.New("file not found"), "load config")
+
+	require.False(t, errors.EqualChains(want, got))
+	require.Equal(t, "layer 1:\n"+
+		"\twant: *errors.withMessage: read config\n"+
+		"\tgot:  *errors.withMessage: load config",
+		errors.Diff(want, got))
+}
+
+func TestDiffData(t *testing.T) {
+	want := errors.WithCode(errors.WithField(io.EOF, "id", 42), 404)
+	got := errors.WithCode(errors.WithField(io.EOF, "id", 43), 500)
+
+	require.Equal(t, "layer 1:\n"+
+		"\twant: *errors.withCode [code=404]\n"+
+		"\tgot:  *errors.withCode [code=500]\n"+
+		"layer 2:\n"+
+		"\twant: *errors.withField {id=42}\n"+
+		"\tgot:  *errors.withField {id=43}",
+		errors.Diff(want, got))
+
+	require.Equal(t, "layer 1:\n"+
+		"\twant: *errors.errorString: EOF\n"+
+		"\tgot:  *errors.errorString: unexpected EOF",
+		errors.Diff(io.EOF, io.ErrUnexpectedEOF))
+}
+
+func TestDiffLength(t *testing.T) {
+	require.Equal(t, "layer 1:\n"+
+		"\twant: *errors.withMessage: read\n"+
+		"\tgot:  *errors.fundamental: read\n"+
+		"layer 2:\n"+
+		"\twant: *errors.errorString: EOF\n"+
+		"\tgot:  <none>",
+		errors.Diff(errors.WithMessage(io.EOF, "read"), errors.NoStack("read")))
+
+	require.Equal(t, "layer 1:\n"+
+		"\twant: <none>\n"+
+		"\tgot:  *errors.errorString: EOF",
+		errors.Diff(nil, io.EOF))
+}
+
+func TestDiffBranches(t *testing.T) {
+	want := errors.Join(io.EOF, errors.WithMessage(io.ErrClosedPipe, "write"))
+	got := errors.Join(io.EOF, errors.WithMessage(io.ErrClosedPipe, "flush"))
+
+	require.Equal(t, "layer 1.2.1:\n"+
+		"\twant: *errors.withMessage: write\n"+
+		"\tgot:  *errors.withMessage: flush",
+		errors.Diff(want, got))
+
+	require.Equal(t, "layer 1:\n"+
+		"\twant: *errors.joined (2 branches)\n"+
+		"\tgot:  *errors.joined (1 branches)",
+		errors.Diff(want, errors.Join(io.EOF)))
+}
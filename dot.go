@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DOT renders err's tree in Graphviz DOT format: every error of the tree
+// (including transparent wrappers and children of multi-errors) becomes a
+// node labeled with its type, own message and origin frame (the newest
+// meaningful frame of its stack trace, or the point, where it was wrapped),
+// and edges go from wrappers to their causes:
+//
+//	digraph errors {
+//		node [shape=box, fontname="monospace"];
+//		e0 [label="*errors.withStack\nsync.Run(sync.go:42)"];
+//		e1 [label="*errors.joined"];
+//		...
+//		e0 -> e1;
+//	}
+//
+// Edges to children of multi-errors are labeled with their indexes. Result
+// can be rendered with "dot -Tsvg". If err is nil, DOT returns empty graph.
+func DOT(err error) string {
+	var b strings.Builder
+	b.WriteString("digraph errors {\n")
+	b.WriteString("\tnode [shape=box, fontname=\"monospace\"];\n")
+	if err != nil {
+		n := 0
+		dotNode(&b, err, &n)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotNode writes err and its causes into b and returns id of err's node. n
+// is a counter of written nodes.
+func dotNode(b *strings.Builder, err error, n *int) string {
+	err = skipAdopted(err)
+	id := "e" + strconv.Itoa(*n)
+	*n++
+
+	lines := []string{fmt.Sprintf("%T", err)}
+	// message of multi-error consists of messages of its children
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		if msg := ownMessage(err); msg != "" {
+			lines = append(lines, msg)
+		}
+	}
+	if origin := dotOrigin(err); origin != "" {
+		lines = append(lines, origin)
+	}
+	b.WriteString("\t" + id + " [label=" + dotQuote(strings.Join(lines, "\n")) + "];\n")
+
+	switch e := err.(type) {
+	case *truncated:
+		// causes repeat messages, which were truncated
+	case interface{ Unwrap() []error }:
+		for i, child := range e.Unwrap() {
+			if child == nil {
+				continue
+			}
+			childID := dotNode(b, child, n)
+			b.WriteString("\t" + id + " -> " + childID + " [label=" + strconv.Itoa(i) + "];\n")
+		}
+	case interface{ Unwrap() error }:
+		if cause := e.Unwrap(); cause != nil {
+			childID := dotNode(b, cause, n)
+			b.WriteString("\t" + id + " -> " + childID + ";\n")
+		}
+	}
+	return id
+}
+
+// dotOrigin returns origin frame of err itself (not of its causes), or empty
+// string, if err doesn't record one.
+func dotOrigin(err error) string {
+	if stack, ok := stackOf(err); ok {
+		for _, f := range stack {
+			if !isRuntimeFrame(f) {
+				return shortFrame(f)
+			}
+		}
+	}
+	if w, ok := err.(interface{ wrappedAt() Frame }); ok && w.wrappedAt() != 0 {
+		return shortFrame(w.wrappedAt())
+	}
+	return ""
+}
+
+// dotQuote quotes s as DOT string, escaping quotes, backslashes and
+// newlines.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
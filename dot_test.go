@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestDOT(t *testing.T) {
+	require.Equal(t, "digraph errors {\n\tnode [shape=box, fontname=\"monospace\"];\n}\n", errors.DOT(nil))
+
+	batch := errors.Join(
+		errors.WithMessage(errors.New(`item "a" failed`), "step 1"),
+		io.EOF,
+	)
+	got := errors.DOT(errors.Wrap(batch, "batch"))
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	require.Equal(t, "digraph errors {", lines[0])
+	require.Equal(t, "}", lines[len(lines)-1])
+
+	origin := `errors_test\.TestDOT\(dot_test\.go:\d+\)`
+	want := []string{
+		`e0 \[label="\*errors\.withMessage\\nbatch\\n` + origin + `"\];`,
+		`e1 \[label="\*errors\.joined"\];`,
+		`e2 \[label="\*errors\.withMessage\\nstep 1"\];`,
+		`e3 \[label="\*errors\.fundamental\\nitem \\"a\\" failed\\n` + origin + `"\];`,
+		`e2 -> e3;`,
+		`e1 -> e2 \[label=0\];`,
+		`e4 \[label="\*errors\.errorString\\nEOF"\];`,
+		`e1 -> e4 \[label=1\];`,
+		`e0 -> e1;`,
+	}
+	require.Len(t, lines, len(want)+3)
+	for i, re := range want {
+		require.Regexp(t, regexp.MustCompile("^\t"+re+"$"), lines[i+2])
+	}
+}
+
+func TestDOTWrapPoint(t *testing.T) {
+	err := errors.Wrap(fmt.Errorf("ctx: %w", errors.New("inner")), "outer")
+	got := errors.DOT(err)
+	require.Regexp(t, `e0 \[label="\*errors\.withMessage\\nouter\\nerrors_test\.TestDOTWrapPoint\(dot_test\.go:\d+\)"\];`, got)
+	require.Contains(t, got, `e1 [label="*fmt.wrapError\nctx"];`)
+}
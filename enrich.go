@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Enricher returns fields, which describe current process or environment,
+// e.g. host, pid, region or pod name. Unlike fields of errors, they are not
+// attached to errors in memory, but added to reporting output (see
+// ToJSONEnriched and report package) when it's produced.
+type Enricher func() []Field
+
+type enricher struct {
+	fn Enricher
+}
+
+var (
+	enrichersMu sync.Mutex
+	enrichers   atomic.Value // []*enricher
+)
+
+// AddEnricher registers e, which is called whenever error is serialized by
+// ToJSONEnriched or reported by report package. It returns function, which
+// unregisters e:
+//
+//	errors.AddEnricher(errors.ProcessInfo)
+//	errors.AddEnricher(errors.EnvEnricher(map[string]string{"region": "REGION", "pod": "POD_NAME"}))
+func AddEnricher(e Enricher) (remove func()) {
+	o := &enricher{fn: e}
+
+	enrichersMu.Lock()
+	defer enrichersMu.Unlock()
+	old, _ := enrichers.Load().([]*enricher)
+	enrichers.Store(append(old[:len(old):len(old)], o))
+
+	return func() {
+		enrichersMu.Lock()
+		defer enrichersMu.Unlock()
+		old, _ := enrichers.Load().([]*enricher)
+		res := make([]*enricher, 0, len(old))
+		for _, other := range old {
+			if other != o {
+				res = append(res, other)
+			}
+		}
+		enrichers.Store(res)
+	}
+}
+
+// Enrichment returns fields of all registered enrichers (see AddEnricher) in
+// order of registration. If there are no enrichers, Enrichment returns nil.
+func Enrichment() []Field {
+	list, _ := enrichers.Load().([]*enricher)
+	var res []Field
+	for _, e := range list {
+		res = append(res, e.fn()...)
+	}
+	return res
+}
+
+// ProcessInfo is an Enricher, which returns "host" (hostname of the machine)
+// and "pid" (identifier of current process) fields.
+func ProcessInfo() []Field {
+	res := []Field{{Key: "pid", Value: os.Getpid()}}
+	if host, err := os.Hostname(); err == nil {
+		res = append([]Field{{Key: "host", Value: host}}, res...)
+	}
+	return res
+}
+
+// EnvEnricher returns an Enricher, which reads fields from environment
+// variables: keys of vars are field keys, values are names of variables,
+// e.g. {"pod": "POD_NAME"}. Unset variables are skipped. Fields are sorted
+// by key.
+func EnvEnricher(vars map[string]string) Enricher {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return func() []Field {
+		var res []Field
+		for _, k := range keys {
+			if v, ok := os.LookupEnv(vars[k]); ok {
+				res = append(res, Field{Key: k, Value: v})
+			}
+		}
+		return res
+	}
+}
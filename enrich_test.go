@@ -0,0 +1,78 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestEnrichment(t *testing.T) {
+	require.Nil(t, errors.Enrichment())
+
+	calls := 0
+	remove := errors.AddEnricher(func() []errors.Field {
+		calls++
+		return []errors.Field{{Key: "region", Value: "eu-west-1"}}
+	})
+	defer remove()
+
+	err := errors.New("boom")
+	require.Zero(t, calls, "enrichers must not be called for in-memory errors")
+	require.Empty(t, errors.Fields(err))
+
+	require.Equal(t, []errors.Field{{Key: "region", Value: "eu-west-1"}}, errors.Enrichment())
+	require.Equal(t, 1, calls)
+
+	remove()
+	require.Empty(t, errors.Enrichment())
+}
+
+func TestEnrichmentJSON(t *testing.T) {
+	defer errors.AddEnricher(func() []errors.Field {
+		return []errors.Field{{Key: "pod", Value: "api-0"}}
+	})()
+
+	plain, err := errors.ToJSON(errors.Wrap(errors.New("boom"), "read"))
+	require.NoError(t, err)
+	require.NotContains(t, string(plain), "context")
+
+	data, err := errors.ToJSONEnriched(errors.Wrap(errors.New("boom"), "read"))
+	require.NoError(t, err)
+
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &res))
+	require.Equal(t, map[string]interface{}{"pod": "api-0"}, res["context"])
+	require.NotContains(t, res["cause"], "context")
+
+	restored, err := errors.FromJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, "read: boom", restored.Error())
+}
+
+func TestProcessInfo(t *testing.T) {
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	require.Equal(t, []errors.Field{
+		{Key: "host", Value: host},
+		{Key: "pid", Value: os.Getpid()},
+	}, errors.ProcessInfo())
+}
+
+func TestEnvEnricher(t *testing.T) {
+	t.Setenv("ERRORS_TEST_REGION", "eu-west-1")
+	t.Setenv("ERRORS_TEST_POD", "api-0")
+
+	e := errors.EnvEnricher(map[string]string{
+		"region": "ERRORS_TEST_REGION",
+		"pod":    "ERRORS_TEST_POD",
+		"zone":   "ERRORS_TEST_UNSET",
+	})
+	require.Equal(t, []errors.Field{
+		{Key: "pod", Value: "api-0"},
+		{Key: "region", Value: "eu-west-1"},
+	}, e())
+}
@@ -0,0 +1,359 @@
+// Package errgen generates typed error catalogs, based on
+// github.com/quenbyako/errors package.
+//
+// Catalog describes errors of some service: their names, message templates,
+// error codes, HTTP statuses, gRPC codes and retry policy. For each error
+// definition errgen generates an error type, typed constructor, checker
+// function and, optionally, remapping rules, so large error taxonomies stay
+// consistent across the service.
+//
+// Catalog can be declared in Go (as a Catalog value) or in YAML file, which
+// is read by cmd/errgen tool:
+//
+//	package: users
+//	imports:
+//	  - database/sql
+//	errors:
+//	  - name: NotFound
+//	    doc: is returned, when user doesn't exist.
+//	    message: user {id} not found
+//	    params:
+//	      - name: id
+//	        type: int64
+//	    code: 1001
+//	    http: 404
+//	    grpc: NotFound
+//	  - name: Unavailable
+//	    message: storage is unavailable
+//	    http: 503
+//	    retryable: true
+//	    remap:
+//	      - sql.ErrConnDone
+package errgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/quenbyako/errors"
+)
+
+// Catalog is a set of error definitions of single package.
+type Catalog struct {
+	// Package is a name of generated package.
+	Package string `yaml:"package"`
+	// Imports are additional imports, required by Remap expressions.
+	Imports []string `yaml:"imports"`
+	// Errors are error definitions.
+	Errors []Definition `yaml:"errors"`
+}
+
+// Definition describes single error of catalog.
+type Definition struct {
+	// Name is a name of error, e.g. NotFound. Generated type is named
+	// NotFoundError, constructor is NewNotFound and checker is IsNotFound.
+	Name string `yaml:"name"`
+	// Doc is a documentation of error, which continues "<Type> " sentence.
+	Doc string `yaml:"doc"`
+	// Message is a template of error message. Parameters are referred as
+	// {name}.
+	Message string `yaml:"message"`
+	// Params are parameters of error, which become fields of error type and
+	// arguments of its constructor.
+	Params []Param `yaml:"params"`
+	// Code is an error code (see errors.WithCode). Zero means no code.
+	Code int `yaml:"code"`
+	// HTTP is an HTTP status of error (see errors.HTTPStatus). Zero means no
+	// status.
+	HTTP int `yaml:"http"`
+	// GRPC is a name of gRPC code of error (e.g. NotFound). Empty means no
+	// code.
+	GRPC string `yaml:"grpc"`
+	// Retryable defines whether operation, failed with this error, can be
+	// retried (see errors.IsRetryable). Nil means unknown.
+	Retryable *bool `yaml:"retryable"`
+	// Remap are Go expressions of errors, which must be remapped to this
+	// one. Only errors without parameters can be remapped.
+	Remap []string `yaml:"remap"`
+}
+
+// Param is a parameter of error definition.
+type Param struct {
+	// Name is a name of parameter in snake_case or lowerCamelCase.
+	Name string `yaml:"name"`
+	// Type is a Go type of parameter.
+	Type string `yaml:"type"`
+}
+
+var grpcCodes = map[string]bool{
+	"OK": true, "Canceled": true, "Unknown": true, "InvalidArgument": true,
+	"DeadlineExceeded": true, "NotFound": true, "AlreadyExists": true,
+	"PermissionDenied": true, "ResourceExhausted": true, "FailedPrecondition": true,
+	"Aborted": true, "OutOfRange": true, "Unimplemented": true, "Internal": true,
+	"Unavailable": true, "DataLoss": true, "Unauthenticated": true,
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// Generate writes Go source of c into w.
+func Generate(w io.Writer, c *Catalog) error {
+	data, err := newFileData(c)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "formatting generated code")
+	}
+	_, err = w.Write(src)
+	return errors.WithStack(err)
+}
+
+type fileData struct {
+	Package   string
+	Imports   []string
+	Fmt       bool
+	GRPC      bool
+	Errors    []defData
+	Remappers bool
+}
+
+type defData struct {
+	Name      string
+	Doc       string
+	Format    string
+	FormatArg []string
+	Params    []paramData
+	Code      int
+	HTTP      int
+	GRPC      string
+	Retryable *bool
+	Remap     []string
+}
+
+type paramData struct {
+	Field string
+	Arg   string
+	Type  string
+}
+
+func newFileData(c *Catalog) (*fileData, error) {
+	if !token.IsIdentifier(c.Package) {
+		return nil, errors.Errorf("invalid package name %q", c.Package)
+	}
+	data := &fileData{Package: c.Package, Imports: c.Imports}
+
+	names := make(map[string]bool, len(c.Errors))
+	for _, d := range c.Errors {
+		def, err := newDefData(d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error %q", d.Name)
+		}
+		if names[def.Name] {
+			return nil, errors.Errorf("duplicate error %q", d.Name)
+		}
+		names[def.Name] = true
+
+		data.Fmt = data.Fmt || len(def.FormatArg) > 0
+		data.GRPC = data.GRPC || def.GRPC != ""
+		data.Remappers = data.Remappers || len(def.Remap) > 0
+		data.Errors = append(data.Errors, def)
+	}
+	return data, nil
+}
+
+func newDefData(d Definition) (defData, error) {
+	if !token.IsIdentifier(d.Name) || !token.IsExported(d.Name) {
+		return defData{}, errors.New("name must be exported identifier")
+	}
+	if d.Message == "" {
+		return defData{}, errors.New("message is empty")
+	}
+	if d.HTTP != 0 && (d.HTTP < 100 || d.HTTP > 599) {
+		return defData{}, errors.Errorf("invalid http status %d", d.HTTP)
+	}
+	if d.GRPC != "" && !grpcCodes[d.GRPC] {
+		return defData{}, errors.Errorf("unknown grpc code %q", d.GRPC)
+	}
+	if len(d.Remap) > 0 && len(d.Params) > 0 {
+		return defData{}, errors.New("error with params can't be remapped")
+	}
+
+	def := defData{
+		Name:      d.Name,
+		Doc:       d.Doc,
+		Code:      d.Code,
+		HTTP:      d.HTTP,
+		GRPC:      d.GRPC,
+		Retryable: d.Retryable,
+		Remap:     d.Remap,
+	}
+	if def.Doc == "" {
+		def.Doc = "is returned with message " + fmt.Sprintf("%q.", d.Message)
+	}
+
+	fields := make(map[string]string, len(d.Params))
+	for _, p := range d.Params {
+		if !token.IsIdentifier(p.Name) || p.Type == "" {
+			return defData{}, errors.Errorf("invalid param %q", p.Name)
+		}
+		param := paramData{Field: fieldName(p.Name), Arg: argName(p.Name), Type: p.Type}
+		fields[p.Name] = param.Field
+		def.Params = append(def.Params, param)
+	}
+
+	// message becomes format of fmt.Sprintf, so literal percent signs must
+	// be escaped, unless there is nothing to format
+	var err error
+	def.Format = placeholder.ReplaceAllStringFunc(strings.ReplaceAll(d.Message, "%", "%%"), func(s string) string {
+		name := s[1 : len(s)-1]
+		field, ok := fields[name]
+		if !ok {
+			err = errors.Errorf("unknown param %q in message", name)
+			return s
+		}
+		def.FormatArg = append(def.FormatArg, "e."+field)
+		return "%v"
+	})
+	if len(def.FormatArg) == 0 {
+		def.Format = d.Message
+	}
+	return def, err
+}
+
+// commonInitialisms are rendered in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"api": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true,
+}
+
+// fieldName converts snake_case or lowerCamelCase name into exported Go
+// identifier.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if commonInitialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+		} else if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// argName converts snake_case or lowerCamelCase name into unexported Go
+// identifier.
+func argName(name string) string {
+	field := fieldName(name)
+	i := 1
+	for i < len(field) && field[i] >= 'A' && field[i] <= 'Z' {
+		i++
+	}
+	if i > 1 && i < len(field) {
+		// keep first letter of the next word in upper case: URLPath -> urlPath
+		i--
+	}
+	arg := strings.ToLower(field[:i]) + field[i:]
+	if token.IsKeyword(arg) {
+		arg += "_"
+	}
+	return arg
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by errgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if .Fmt }}
+	"fmt"
+{{- end }}
+{{- range .Imports }}
+	"{{ . }}"
+{{- end }}
+{{- if .GRPC }}
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+{{- end }}
+
+	"github.com/quenbyako/errors"
+)
+
+{{ range .Errors }}
+// {{ .Name }}Error {{ .Doc }}
+{{- if .Params }}
+type {{ .Name }}Error struct {
+{{- range .Params }}
+	{{ .Field }} {{ .Type }}
+{{- end }}
+}
+{{- else }}
+type {{ .Name }}Error struct{}
+{{- end }}
+
+// New{{ .Name }} returns {{ .Name }}Error with stack trace.
+func New{{ .Name }}({{ range $i, $p := .Params }}{{ if $i }}, {{ end }}{{ $p.Arg }} {{ $p.Type }}{{ end }}) error {
+	var err error = &{{ .Name }}Error{
+{{- range .Params }}
+		{{ .Field }}: {{ .Arg }},
+{{- end }}
+	}
+{{- if .Code }}
+	err = errors.WithCode(err, {{ .Code }})
+{{- end }}
+	return errors.WithStack(err)
+}
+
+// Is{{ .Name }} reports whether any error in err's chain is {{ .Name }}Error.
+func Is{{ .Name }}(err error) bool {
+	var target *{{ .Name }}Error
+	return errors.As(err, &target)
+}
+
+func (e *{{ .Name }}Error) Error() string {
+{{- if .FormatArg }}
+	return fmt.Sprintf({{ printf "%q" .Format }}{{ range .FormatArg }}, {{ . }}{{ end }})
+{{- else }}
+	return {{ printf "%q" .Format }}
+{{- end }}
+}
+{{- if .HTTP }}
+
+// HTTPStatus returns HTTP status of the error.
+func (e *{{ .Name }}Error) HTTPStatus() int { return {{ .HTTP }} }
+{{- end }}
+{{- if .GRPC }}
+
+// GRPCStatus returns gRPC status of the error.
+func (e *{{ .Name }}Error) GRPCStatus() *status.Status { return status.New(codes.{{ .GRPC }}, e.Error()) }
+{{- end }}
+{{- if .Retryable }}
+
+// Retryable reports whether operation, failed with the error, can be retried.
+func (e *{{ .Name }}Error) Retryable() bool { return {{ .Retryable }} }
+{{- end }}
+{{ end }}
+{{- if .Remappers }}
+// Remappers remap errors of other packages into errors of the catalog.
+// Original errors are kept as causes of remapped ones.
+var Remappers = []errors.ErrRemapperFunc{
+{{- range $def := .Errors }}
+{{- range .Remap }}
+	errors.IsRemapperFunc({{ . }}, func(err error) error { return errors.WithOriginal(New{{ $def.Name }}(), err) }),
+{{- end }}
+{{- end }}
+}
+{{- end }}
+`))
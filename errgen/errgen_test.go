@@ -0,0 +1,99 @@
+package errgen_test
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors/errgen"
+)
+
+func TestGenerate(t *testing.T) {
+	retryable := true
+	c := &errgen.Catalog{
+		Package: "users",
+		Imports: []string{"database/sql"},
+		Errors: []errgen.Definition{{
+			Name:    "NotFound",
+			Doc:     "is returned, when user doesn't exist.",
+			Message: "user {user_id} not found",
+			Params:  []errgen.Param{{Name: "user_id", Type: "int64"}},
+			Code:    1001,
+			HTTP:    404,
+			GRPC:    "NotFound",
+		}, {
+			Name:      "Unavailable",
+			Message:   "storage is unavailable",
+			HTTP:      503,
+			Retryable: &retryable,
+			Remap:     []string{"sql.ErrConnDone"},
+		}, {
+			Name:    "QuotaExceeded",
+			Message: "{percent}% of quota used",
+			Params:  []errgen.Param{{Name: "percent", Type: "int"}},
+		}, {
+			Name:    "Full",
+			Message: "disk is 100% full",
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, errgen.Generate(&buf, c))
+	src := buf.String()
+
+	_, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0)
+	require.NoError(t, err, src)
+
+	for _, want := range []string{
+		"// Code generated by errgen. DO NOT EDIT.",
+		"package users",
+		"// NotFoundError is returned, when user doesn't exist.",
+		"type NotFoundError struct {\n\tUserID int64\n}",
+		"func NewNotFound(userID int64) error {",
+		"err = errors.WithCode(err, 1001)",
+		`return fmt.Sprintf("user %v not found", e.UserID)`,
+		"func (e *NotFoundError) HTTPStatus() int { return 404 }",
+		"status.New(codes.NotFound, e.Error())",
+		"func IsNotFound(err error) bool {",
+		"// UnavailableError is returned with message \"storage is unavailable\".",
+		`return "storage is unavailable"`,
+		"type UnavailableError struct{}",
+		"func (e *UnavailableError) Retryable() bool { return true }",
+		"errors.IsRemapperFunc(sql.ErrConnDone, func(err error) error { return errors.WithOriginal(NewUnavailable(), err) }),",
+		`return fmt.Sprintf("%v%% of quota used", e.Percent)`,
+		`return "disk is 100% full"`,
+	} {
+		require.Contains(t, src, want)
+	}
+	require.NotContains(t, src, "func (e *NotFoundError) Retryable()")
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		def  errgen.Definition
+		want string
+	}{
+		{"unexported", errgen.Definition{Name: "notFound", Message: "x"}, `error "notFound": name must be exported identifier`},
+		{"no message", errgen.Definition{Name: "NotFound"}, `error "NotFound": message is empty`},
+		{"http", errgen.Definition{Name: "NotFound", Message: "x", HTTP: 42}, `error "NotFound": invalid http status 42`},
+		{"grpc", errgen.Definition{Name: "NotFound", Message: "x", GRPC: "Missing"}, `error "NotFound": unknown grpc code "Missing"`},
+		{"placeholder", errgen.Definition{Name: "NotFound", Message: "{id}"}, `error "NotFound": unknown param "id" in message`},
+		{"remap params", errgen.Definition{
+			Name:    "NotFound",
+			Message: "{id}",
+			Params:  []errgen.Param{{Name: "id", Type: "int"}},
+			Remap:   []string{"io.EOF"},
+		}, `error "NotFound": error with params can't be remapped`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &errgen.Catalog{Package: "users", Errors: []errgen.Definition{tt.def}}
+			require.EqualError(t, errgen.Generate(&bytes.Buffer{}, c), tt.want)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Errgroup is a collection of goroutines working on subtasks of the same
+// task. It behaves like golang.org/x/sync/errgroup.Group, but every error
+// returned by goroutine is annotated with stack trace of the point, where the
+// goroutine was spawned. Zero value is ready to use and returns only the
+// first error, see CollectAll.
+type Errgroup struct {
+	wg     sync.WaitGroup
+	cancel func()
+	all    bool
+
+	once sync.Once
+	err  error
+	errs Group
+}
+
+// ErrgroupOption configures Errgroup.
+type ErrgroupOption func(*Errgroup)
+
+// CollectAll makes Errgroup collect errors of all goroutines instead of only
+// the first one. Wait returns them joined (see Group.Err).
+func CollectAll() ErrgroupOption {
+	return func(g *Errgroup) { g.all = true }
+}
+
+// NewErrgroup returns a new Errgroup configured with options.
+func NewErrgroup(opts ...ErrgroupOption) *Errgroup {
+	g := &Errgroup{errs: Group{mu: new(sync.Mutex)}}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// ErrgroupWithContext returns a new Errgroup and an associated context
+// derived from ctx. The derived context is canceled the first time a
+// function passed to Go returns a non-nil error or the first time Wait
+// returns, whichever occurs first.
+func ErrgroupWithContext(ctx context.Context, opts ...ErrgroupOption) (*Errgroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := NewErrgroup(opts...)
+	g.cancel = cancel
+	return g, ctx
+}
+
+// Go calls the given function in a new goroutine. Error returned by f is
+// annotated with stack trace of the point Go was called.
+func (g *Errgroup) Go(f func() error) {
+	stack := callers(1)
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		err := f()
+		if err == nil {
+			return
+		}
+		err = &withStack{err, stack}
+
+		if g.all {
+			g.errs.Add(err)
+		}
+		g.once.Do(func() {
+			g.err = err
+			if g.cancel != nil {
+				g.cancel()
+			}
+		})
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns the first non-nil error (or all errors, if CollectAll option
+// is set) from them.
+func (g *Errgroup) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	if g.all {
+		return g.errs.Err()
+	}
+	return g.err
+}
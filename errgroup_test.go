@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestErrgroup(t *testing.T) {
+	var g errors.Errgroup
+	g.Go(func() error { return nil })
+	require.NoError(t, g.Wait())
+
+	g.Go(func() error { return io.EOF })
+	err := g.Wait()
+	require.True(t, stderrors.Is(err, io.EOF))
+	require.Equal(t, errors.PkgName+".TestErrgroup", funcName(errors.Stack(err)[0]))
+}
+
+func TestErrgroupCollectAll(t *testing.T) {
+	g, ctx := errors.ErrgroupWithContext(context.Background(), errors.CollectAll())
+	for i := 0; i < 3; i++ {
+		g.Go(func() error { return errors.New("failed") })
+	}
+	g.Go(func() error { return nil })
+
+	err := g.Wait()
+	require.Len(t, errors.Joined(err), 3)
+	require.Len(t, errors.Stacks(err), 6)
+	require.Error(t, ctx.Err())
+}
+
+func TestErrgroupWithContext(t *testing.T) {
+	g, ctx := errors.ErrgroupWithContext(context.Background())
+	g.Go(func() error { return io.EOF })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	require.True(t, stderrors.Is(g.Wait(), io.EOF))
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 )
@@ -17,27 +18,62 @@ type fundamental struct {
 // New also records the stack trace at the point it was called.
 func New(text string) error { return newFundamental(text, 1) }
 
+// NewSkip is like New, but skips skip additional frames of the stack trace:
+// 0 means the caller of NewSkip, 1 means its caller, and so on. It lets
+// helper functions exclude themselves from recorded traces.
+func NewSkip(text string, skip int) error { return newFundamental(text, 1+skipOf(skip)) }
+
+// skipOf converts user supplied skip to extraSkip of internal constructors.
+func skipOf(skip int) uint {
+	if skip < 0 {
+		return 0
+	}
+	return uint(skip)
+}
+
 // Errorf formats according to a format specifier and returns the string
 // as a value that satisfies error.
 // Errorf also records the stack trace at the point it was called.
+//
+// Operands of %w verbs (one or several) become causes of returned error, the
+// same way as for fmt.Errorf, and stack trace is handled as Wrap does: if
+// causes already have a stack trace, only the frame where Errorf is called is
+// recorded. So these calls are equivalent:
+//
+//	errors.Errorf("parse %s: %w", name, err)
+//	errors.Wrapf(err, "parse %s", name)
 func Errorf(format string, args ...interface{}) error {
-	return newFundamental(fmt.Sprintf(format, args...), 1)
+	return errorf(fmt.Errorf(format, args...), 1)
+}
+
+// NoStack returns an error with the supplied message, but without stack
+// trace. It's useful for expected control-flow errors (cache misses, end of
+// iteration, etc.) on hot paths, where stack capture is unwanted. Timestamps,
+// labels and build info are still attached, if enabled, but OnCreate
+// observers aren't notified, since there is no stack trace to report.
+func NoStack(text string) error { return created(&fundamental{msg: text}) }
+
+// NoStackf formats according to a format specifier and returns the string
+// as a value that satisfies error. NoStackf doesn't record stack trace.
+func NoStackf(format string, args ...interface{}) error {
+	return created(&fundamental{msg: fmt.Sprintf(format, args...)})
 }
 
 func newFundamental(text string, extraSkip uint) error {
-	return &fundamental{
+	return created(&fundamental{
 		msg:   text,
 		stack: callers(1 + extraSkip),
-	}
+	})
 }
 
 func (f *fundamental) Error() string          { return f.msg }
 func (f *fundamental) stackTrace() StackTrace { return f.stack }
+func (f *fundamental) message() string        { return f.msg }
 
 func (f *fundamental) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
-		if s.Flag('+') {
+		if s.Flag('+') && len(f.stack) > 0 {
 			io.WriteString(s, f.msg+"\n")
 			f.stack.Format(s, verb)
 			return
@@ -59,25 +95,35 @@ type withStack struct {
 // If err is nil, WithStack returns nil.
 func WithStack(err error) error { return wStack(err, 1) }
 
+// WithStackSkip is like WithStack, but skips skip additional frames of the
+// stack trace, see NewSkip.
+// If err is nil, WithStackSkip returns nil.
+func WithStackSkip(err error, skip int) error { return wStack(err, 1+skipOf(skip)) }
+
 func wStack(err error, extraSkip uint) error {
 	if err == nil {
 		return nil
 	}
-	return &withStack{
+	return created(&withStack{
 		err,
 		callers(1 + extraSkip),
-	}
+	})
 }
 
 func (w *withStack) Unwrap() error          { return w.error }
 func (w *withStack) stackTrace() StackTrace { return w.stack }
+func (w *withStack) message() string        { return "" }
 
 func (w *withStack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			fmt.Fprintf(s, "%+v\n", w.error)
-			w.stack.Format(s, verb)
+			formatDetailed(s, w.error)
+			if len(w.stack) > 0 {
+				io.WriteString(s, "\n")
+				w.stack.Format(s, verb)
+			}
+			formatFooter(s, w.error)
 			return
 		}
 		fallthrough
@@ -91,6 +137,9 @@ func (w *withStack) Format(s fmt.State, verb rune) {
 type withMessage struct {
 	cause error
 	msg   string
+	// at is a point, where error was wrapped, if its cause already had a
+	// stack trace.
+	at Frame
 }
 
 // WithMessage annotates err with a new message.
@@ -117,6 +166,8 @@ func wMessage(err error, message string) error {
 
 // Wrap returns an error annotating err with a stack trace
 // at the point Wrap is called, and the supplied message.
+// If err already has a stack trace, only the frame where Wrap is called is
+// recorded, and it's printed after the original trace in %+v format.
 // If err is nil, Wrap returns nil.
 func Wrap(err error, message string) error {
 	return wrap(err, message, 1)
@@ -124,36 +175,86 @@ func Wrap(err error, message string) error {
 
 // Wrapf returns an error annotating err with a stack trace
 // at the point Wrapf is called, and the format specifier.
+// Operands of %w verbs in format are printed as with %v and become causes of
+// returned error along with err, see Errorf.
 // If err is nil, Wrapf returns nil.
 func Wrapf(err error, format string, args ...interface{}) error {
-	return wrap(err, fmt.Sprintf(format, args...), 1)
+	if err == nil {
+		return nil
+	}
+	return wrapf(err, fmt.Errorf(format, args...), 1)
 }
 
-func wrap(err error, message string, extraSkip uint) error {
+// WrapSkip is like Wrap, but skips skip additional frames of the stack trace
+// (or of the wrap point, if err already has a stack trace), see NewSkip.
+// If err is nil, WrapSkip returns nil.
+func WrapSkip(err error, message string, skip int) error {
+	return wrap(err, message, 1+skipOf(skip))
+}
+
+// WrapHere returns an error annotating err with the supplied message and a
+// stack trace at the point WrapHere is called. Unlike Wrap, the stack trace
+// is recorded even if err already has one: Stack returns the new trace, and
+// all the traces of the chain are available with Stacks.
+// If err is nil, WrapHere returns nil.
+func WrapHere(err error, message string) error {
+	return wrapHere(err, message, 1)
+}
+
+// WrapHeref is like WrapHere, but with the format specifier.
+// If err is nil, WrapHeref returns nil.
+func WrapHeref(err error, format string, args ...interface{}) error {
+	return wrapHere(err, fmt.Sprintf(format, args...), 1)
+}
+
+func wrapHere(err error, message string, extraSkip uint) error {
 	if err == nil {
 		return nil
 	}
-	err = &withMessage{
-		cause: err,
-		msg:   message,
+	return created(&withStack{
+		&withMessage{
+			cause: err,
+			msg:   message,
+		},
+		callers(1 + extraSkip),
+	})
+}
+
+func wrap(err error, message string, extraSkip uint) error {
+	if err == nil {
+		return nil
 	}
 	if Stack(err) != nil {
-		return err
+		var at Frame
+		if stack := callersDepth(1+extraSkip, 1); len(stack) > 0 {
+			at = stack[0]
+		}
+		return created(&withMessage{
+			cause: err,
+			msg:   message,
+			at:    at,
+		})
 	}
-	return &withStack{
-		err,
+	return created(&withStack{
+		&withMessage{
+			cause: err,
+			msg:   message,
+		},
 		callers(1 + extraSkip),
-	}
+	})
 }
 
-func (w *withMessage) Error() string { return w.msg + ": " + w.cause.Error() }
-func (w *withMessage) Unwrap() error { return w.cause }
+func (w *withMessage) Error() string    { return joinMessage(w.msg, w.cause) }
+func (w *withMessage) Unwrap() error    { return w.cause }
+func (w *withMessage) message() string  { return w.msg }
+func (w *withMessage) wrappedAt() Frame { return w.at }
 
 func (w *withMessage) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			fmt.Fprintf(s, "%s: %+v", w.msg, w.cause)
+			formatMessage(s, w.msg, w.cause, w.at)
+			formatFooter(s, w.cause)
 			return
 		}
 		fallthrough
@@ -164,14 +265,52 @@ func (w *withMessage) Format(s fmt.State, verb rune) {
 	}
 }
 
-// Stack returns stack trace of error
+// formatMessage writes message of wrapper and its cause in extended format.
+// If wrap point is known, it's printed after details of the cause.
+func formatMessage(s io.Writer, msg string, cause error, at Frame) {
+	formatWrapped(s, msg+loadSeparator(), msg, cause, at)
+}
+
+// formatWrapped is like formatMessage, but head is written before details of
+// the cause instead of message and separator.
+func formatWrapped(s io.Writer, head, msg string, cause error, at Frame) {
+	io.WriteString(s, head)
+	if at == 0 {
+		formatDetailed(s, cause)
+		return
+	}
+	var buf bytes.Buffer
+	formatDetailedVerb(&buf, cause, detailVerb(s))
+	if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	s.Write(buf.Bytes())
+	fmt.Fprintf(s, "%+v: %s", at, msg)
+	if detailVerb(s) == "%#+v" {
+		io.WriteString(s, sourceSnippet(at))
+	}
+	io.WriteString(s, "\n")
+}
+
+// Stack returns stack trace of error: the outermost stack trace recorded in
+// err's chain. Multi-errors (errors with Unwrap() []error method) are
+// followed into their branches in order, so stack trace of the first branch,
+// which has one, is returned. Use StackAll to get stack traces of all
+// branches.
 func Stack(err error) StackTrace {
 	if err == nil {
 		return nil
 	}
-	cause, ok := err.(interface{ stackTrace() StackTrace })
-	if ok {
-		return cause.stackTrace()
+	if stack, ok := stackOf(err); ok && len(stack) > 0 {
+		return stack
+	}
+	if m, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, child := range m.Unwrap() {
+			if stack := Stack(child); stack != nil {
+				return stack
+			}
+		}
+		return nil
 	}
 	return Stack(Unwrap(err))
 }
@@ -180,7 +319,8 @@ func Stack(err error) StackTrace {
 //
 // If the error does not implement Unwrap, the original error will
 // be returned. If the error is nil, nil will be returned without further
-// investigation.
+// investigation. Cause stops at multi-errors (errors with Unwrap() []error
+// method) and returns them, use CauseAll to get causes of every branch.
 func Cause(err error) error {
 	for err != nil {
 		cause, ok := err.(interface{ Unwrap() error })
@@ -0,0 +1,31 @@
+//go:build go1.18
+
+package errors
+
+// WrapAs wraps err into a domain error type T, created by construct, so the
+// wrapper itself can be matched later with As. If err has no stack trace,
+// construct receives err annotated with the stack trace at the point WrapAs
+// was called, so stack is available through the wrapper's Unwrap:
+//
+//	type StorageError struct{ Err error }
+//
+//	func (e *StorageError) Error() string { return "storage: " + e.Err.Error() }
+//	func (e *StorageError) Unwrap() error { return e.Err }
+//
+//	if e, ok := errors.WrapAs(err, func(err error) *StorageError { return &StorageError{err} }); ok {
+//		return e
+//	}
+//
+// If err is nil, WrapAs returns zero value of T and false. The result must
+// not be returned as error without checking ok: zero value of pointer type T
+// is a typed nil, which is not equal to nil, when converted to error.
+func WrapAs[T error](err error, construct func(error) T) (T, bool) {
+	if err == nil {
+		var zero T
+		return zero, false
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	return construct(err), true
+}
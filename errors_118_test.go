@@ -0,0 +1,42 @@
+//go:build go1.18
+
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type storageError struct{ err error }
+
+func (e *storageError) Error() string { return "storage: " + e.err.Error() }
+func (e *storageError) Unwrap() error { return e.err }
+
+func newStorageError(err error) *storageError { return &storageError{err} }
+
+func TestWrapAs(t *testing.T) {
+	err, ok := errors.WrapAs(io.EOF, newStorageError)
+	require.True(t, ok)
+	require.EqualError(t, err, "storage: EOF")
+	require.True(t, errors.Is(err, io.EOF))
+
+	var target *storageError
+	require.True(t, errors.As(errors.Wrap(err, "get"), &target))
+	require.Equal(t, err, target)
+
+	stack := errors.Stack(err)
+	require.NotNil(t, stack)
+	require.Equal(t, errors.PkgName+".TestWrapAs", funcName(stack[0]))
+
+	cause := errors.New("cause")
+	wrapped, _ := errors.WrapAs(cause, newStorageError)
+	require.Equal(t, errors.Stack(cause), errors.Stack(wrapped))
+
+	wrapped, ok = errors.WrapAs(nil, newStorageError)
+	require.False(t, ok)
+	require.Nil(t, wrapped)
+}
@@ -249,3 +249,98 @@ func TestErrorEquality(t *testing.T) {
 		}
 	}
 }
+
+func TestNoStack(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{NoStack("cache miss"), "cache miss"},
+		{NoStackf("cache miss: %d", 42), "cache miss: 42"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("NoStack: got: %q, want %q", got, tt.want)
+		}
+		if got := fmt.Sprintf("%+v", tt.err); got != tt.want {
+			t.Errorf("NoStack %%+v: got: %q, want %q", got, tt.want)
+		}
+		if st := Stack(tt.err); st != nil {
+			t.Errorf("NoStack: got stack %v, want nil", st)
+		}
+	}
+}
+
+func TestWrapHere(t *testing.T) {
+	if got := WrapHere(nil, "no error"); got != nil {
+		t.Errorf("WrapHere(nil, \"no error\"): got %#v, expected nil", got)
+	}
+
+	origin := New("error")
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{WrapHere(origin, "read error"), "read error: error"},
+		{WrapHeref(origin, "read error %d", 42), "read error 42: error"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("WrapHere: got: %q, want %q", got, tt.want)
+		}
+		stacks := Stacks(tt.err)
+		if len(stacks) != 2 {
+			t.Fatalf("WrapHere: got %d stacks, want 2", len(stacks))
+		}
+		if !reflect.DeepEqual(stacks[1], Stack(origin)) {
+			t.Errorf("WrapHere: origin stack %v, want %v", stacks[1], Stack(origin))
+		}
+		if !reflect.DeepEqual(stacks[0], Stack(tt.err)) {
+			t.Errorf("WrapHere: got stack %v, want %v", Stack(tt.err), stacks[0])
+		}
+		if reflect.DeepEqual(stacks[0], stacks[1]) {
+			t.Errorf("WrapHere: new stack is equal to origin one")
+		}
+	}
+}
+
+func newHelper(text string) error                { return NewSkip(text, 1) }
+func withStackHelper(err error) error            { return WithStackSkip(err, 1) }
+func wrapHelper(err error, message string) error { return WrapSkip(err, message, 1) }
+
+func TestSkip(t *testing.T) {
+	if got := WithStackSkip(nil, 1); got != nil {
+		t.Errorf("WithStackSkip(nil, 1): got %#v, expected nil", got)
+	}
+	if got := WrapSkip(nil, "no error", 1); got != nil {
+		t.Errorf("WrapSkip(nil, \"no error\", 1): got %#v, expected nil", got)
+	}
+
+	want := frameFunc(Callers(0)[0])
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"NewSkip", newHelper("error")},
+		{"WithStackSkip", withStackHelper(io.EOF)},
+		{"WrapSkip", wrapHelper(io.EOF, "read")},
+		{"NewSkipNegative", NewSkip("error", -1)},
+	}
+	for _, tt := range tests {
+		if got := frameFunc(Stack(tt.err)[0]); got != want {
+			t.Errorf("%s: got top frame %q, want %q", tt.name, got, want)
+		}
+	}
+
+	err := wrapHelper(New("error"), "read")
+	if got := frameFunc(err.(*withMessage).at); got != want {
+		t.Errorf("WrapSkip with stack: got wrap point %q, want %q", got, want)
+	}
+}
+
+func frameFunc(f Frame) string {
+	_, _, name := f.FuncInfo()
+	return name
+}
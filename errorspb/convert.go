@@ -0,0 +1,179 @@
+// Package errorspb provides protobuf representation of error chains of
+// github.com/quenbyako/errors package, so services, exchanging protobuf
+// messages, can pass full error chains with stack frames, codes and fields.
+//
+// Representation is defined in errors.proto and mirrors JSON representation
+// of errors.ToJSON.
+package errorspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative errors.proto
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/quenbyako/errors"
+)
+
+// node mirrors JSON representation of single error in the chain, produced by
+// errors.ToJSON.
+type node struct {
+	Message string                 `json:"message"`
+	Stack   errors.StackTrace      `json:"stack,omitempty"`
+	At      errors.Frame           `json:"at,omitempty"`
+	Code    *int                   `json:"code,omitempty"`
+	Kind    string                 `json:"kind,omitempty"`
+	Build   *errors.BuildInfo      `json:"build,omitempty"`
+	Hint    string                 `json:"hint,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Cause   *node                  `json:"cause,omitempty"`
+	Causes  []*node                `json:"causes,omitempty"`
+}
+
+// ToProto returns protobuf representation of err's chain. Each error in the
+// chain becomes its own message, errors aggregated by multi-errors are
+// listed in causes. Field values must be representable as
+// google.protobuf.Value.
+// If err is nil, ToProto returns nil.
+func ToProto(err error) (*Error, error) {
+	if err == nil {
+		return nil, nil
+	}
+	data, err := errors.ToJSON(err)
+	if err != nil {
+		return nil, err
+	}
+	var n *node
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return toProto(n)
+}
+
+func toProto(n *node) (*Error, error) {
+	if n == nil {
+		return nil, nil
+	}
+	res := &Error{
+		Message: n.Message,
+		At:      toProtoFrame(n.At),
+		Kind:    n.Kind,
+		Hint:    n.Hint,
+	}
+	for _, f := range n.Stack {
+		res.Stack = append(res.Stack, toProtoFrame(f))
+	}
+	if n.Code != nil {
+		code := int64(*n.Code)
+		res.Code = &code
+	}
+	if b := n.Build; b != nil {
+		res.Build = &BuildInfo{Path: b.Path, Version: b.Version, Revision: b.Revision, Dirty: b.Dirty}
+	}
+	if len(n.Fields) > 0 {
+		res.Fields = make(map[string]*structpb.Value, len(n.Fields))
+		for k, v := range n.Fields {
+			value, err := structpb.NewValue(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "field %q", k)
+			}
+			res.Fields[k] = value
+		}
+	}
+
+	var err error
+	if res.Cause, err = toProto(n.Cause); err != nil {
+		return nil, err
+	}
+	for _, child := range n.Causes {
+		c, err := toProto(child)
+		if err != nil {
+			return nil, err
+		}
+		res.Causes = append(res.Causes, c)
+	}
+	return res, nil
+}
+
+func toProtoFrame(f errors.Frame) *Frame {
+	if f == 0 {
+		return nil
+	}
+	file, line, name := f.FuncInfo()
+	return &Frame{Function: name, File: file, Line: int64(line)}
+}
+
+// FromProto reconstructs error chain from its protobuf representation,
+// produced by ToProto (possibly by another service). Reconstructed errors
+// behave the same way as errors, reconstructed by errors.FromJSON.
+// If e is nil, FromProto returns nil error.
+func FromProto(e *Error) (error, error) {
+	if e == nil {
+		return nil, nil
+	}
+	n, err := fromProto(e)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(n)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return errors.FromJSON(data)
+}
+
+func fromProto(e *Error) (*node, error) {
+	if e == nil {
+		return nil, nil
+	}
+	res := &node{Message: e.GetMessage(), Kind: e.GetKind(), Hint: e.GetHint()}
+
+	var err error
+	if res.At, err = fromProtoFrame(e.GetAt()); err != nil {
+		return nil, err
+	}
+	for _, f := range e.GetStack() {
+		frame, err := fromProtoFrame(f)
+		if err != nil {
+			return nil, err
+		}
+		res.Stack = append(res.Stack, frame)
+	}
+	if e.Code != nil {
+		code := int(e.GetCode())
+		res.Code = &code
+	}
+	if b := e.GetBuild(); b != nil {
+		res.Build = &errors.BuildInfo{Path: b.GetPath(), Version: b.GetVersion(), Revision: b.GetRevision(), Dirty: b.GetDirty()}
+	}
+	if len(e.GetFields()) > 0 {
+		res.Fields = make(map[string]interface{}, len(e.GetFields()))
+		for k, v := range e.GetFields() {
+			res.Fields[k] = v.AsInterface()
+		}
+	}
+
+	if res.Cause, err = fromProto(e.GetCause()); err != nil {
+		return nil, err
+	}
+	for _, child := range e.GetCauses() {
+		c, err := fromProto(child)
+		if err != nil {
+			return nil, err
+		}
+		res.Causes = append(res.Causes, c)
+	}
+	return res, nil
+}
+
+func fromProtoFrame(f *Frame) (errors.Frame, error) {
+	var res errors.Frame
+	if f == nil || f.GetFunction() == "" {
+		return res, nil
+	}
+	text := f.GetFunction() + " " + f.GetFile() + ":" + strconv.FormatInt(f.GetLine(), 10)
+	err := res.UnmarshalText([]byte(text))
+	return res, err
+}
@@ -0,0 +1,95 @@
+package errorspb_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/errorspb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	orig := errors.Wrap(
+		errors.WithField(errors.WithKind(errors.WithCode(errors.New("not found"), 404), errors.KindNotFound), "id", 42),
+		"get user",
+	)
+
+	pb, err := errorspb.ToProto(orig)
+	require.NoError(t, err)
+	require.Equal(t, "get user: not found", pb.GetMessage())
+	require.NotNil(t, pb.GetAt())
+
+	data, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	var decoded errorspb.Error
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	got, err := errorspb.FromProto(&decoded)
+	require.NoError(t, err)
+	require.EqualError(t, got, orig.Error())
+	require.Equal(t, fmt.Sprintf("%+v", orig), fmt.Sprintf("%+v", got))
+	require.Len(t, errors.Stack(got), len(errors.Stack(orig)))
+	require.True(t, errors.IsCode(got, 404))
+	require.Equal(t, errors.KindNotFound, errors.KindOf(got))
+	require.Equal(t, map[string]interface{}{"id": float64(42)}, errors.Fields(got))
+}
+
+func TestRoundTripJoined(t *testing.T) {
+	orig := errors.Join(errors.New("first"), errors.Wrap(io.EOF, "second"))
+
+	pb, err := errorspb.ToProto(orig)
+	require.NoError(t, err)
+	require.Len(t, pb.GetCauses(), 2)
+
+	got, err := errorspb.FromProto(pb)
+	require.NoError(t, err)
+	require.EqualError(t, got, orig.Error())
+	require.Len(t, errors.Stacks(got), 2)
+}
+
+func TestNil(t *testing.T) {
+	pb, err := errorspb.ToProto(nil)
+	require.NoError(t, err)
+	require.Nil(t, pb)
+
+	got, err := errorspb.FromProto(nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+func TestRoundTripBuildInfo(t *testing.T) {
+	info := errors.BuildInfo{Path: "example.com/app", Version: "v1.2.3", Revision: "0123abc", Dirty: true}
+	orig := errors.Wrap(errors.WithBuildInfo(io.EOF, info), "call")
+
+	pb, err := errorspb.ToProto(orig)
+	require.NoError(t, err)
+	data, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	var decoded errorspb.Error
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	got, err := errorspb.FromProto(&decoded)
+	require.NoError(t, err)
+	gotInfo, ok := errors.BuildInfoOf(got)
+	require.True(t, ok)
+	require.Equal(t, info, gotInfo)
+}
+
+func TestRoundTripHints(t *testing.T) {
+	orig := errors.WithHint(errors.Wrap(errors.WithHint(io.EOF, "check network"), "read"), "retry later")
+
+	pb, err := errorspb.ToProto(orig)
+	require.NoError(t, err)
+	data, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	var decoded errorspb.Error
+	require.NoError(t, proto.Unmarshal(data, &decoded))
+
+	got, err := errorspb.FromProto(&decoded)
+	require.NoError(t, err)
+	require.Equal(t, []string{"retry later", "check network"}, errors.Hints(got))
+}
@@ -0,0 +1,16 @@
+module github.com/quenbyako/errors/errorspb
+
+go 1.20
+
+require (
+	github.com/quenbyako/errors v0.0.0-20261016165627-c6d3347d4d0a
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,162 @@
+// Package errtest provides test assertions for errors of
+// github.com/quenbyako/errors package: checking chains, messages, recorded
+// stack traces and formatted output.
+//
+// Each Require function marks test as failed and stops its execution with
+// t.FailNow, if assertion doesn't hold.
+package errtest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/quenbyako/errors"
+)
+
+// UpdateEnv is environment variable, which makes RequireGolden overwrite
+// golden files with actual output instead of comparing them, if it's set to
+// non-empty value:
+//
+//	ERRTEST_UPDATE=1 go test ./...
+const UpdateEnv = "ERRTEST_UPDATE"
+
+// RequireIs requires errors.Is(err, target) to be true.
+func RequireIs(t testing.TB, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Fatalf("error chain doesn't contain target:\nerror:  %v\ntarget: %v", err, target)
+	}
+}
+
+// RequireNotIs requires errors.Is(err, target) to be false.
+func RequireNotIs(t testing.TB, err, target error) {
+	t.Helper()
+	if errors.Is(err, target) {
+		t.Fatalf("error chain unexpectedly contains target:\nerror:  %v\ntarget: %v", err, target)
+	}
+}
+
+// RequireAs requires errors.As(err, target) to be true. target must be a
+// non-nil pointer, as for errors.As.
+func RequireAs(t testing.TB, err error, target interface{}) {
+	t.Helper()
+	if !errors.As(err, target) {
+		t.Fatalf("error chain doesn't contain %v:\nerror: %v", reflect.TypeOf(target).Elem(), err)
+	}
+}
+
+// RequireMessage requires message of err to match regular expression
+// pattern.
+func RequireMessage(t testing.TB, err error, pattern string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected error matching %q, got nil", pattern)
+	}
+	if !regexp.MustCompile(pattern).MatchString(err.Error()) {
+		t.Fatalf("error message doesn't match:\nmessage: %q\npattern: %q", err.Error(), pattern)
+	}
+}
+
+// RequireStackContains requires one of stack traces of err (see
+// errors.Stacks) to contain frame of function fn. fn is either full function
+// name ("github.com/foo/bar.Baz") or its suffix after package path
+// separator ("bar.Baz", "bar.(*T).Method").
+func RequireStackContains(t testing.TB, err error, fn string) {
+	t.Helper()
+	stacks := errors.Stacks(err)
+	for _, st := range stacks {
+		for _, f := range st {
+			_, _, name := f.FuncInfo()
+			if name == fn || strings.HasSuffix(name, "/"+fn) {
+				return
+			}
+		}
+	}
+	t.Fatalf("stack traces of error don't contain %s:\nerror: %v\nstacks:\n%s", fn, err, formatStacks(stacks))
+}
+
+func formatStacks(stacks []errors.StackTrace) string {
+	var b strings.Builder
+	for _, st := range stacks {
+		fmt.Fprintf(&b, "%+v", st)
+	}
+	return b.String()
+}
+
+// RequireFormat requires err, formatted with format (e.g. "%+v"), to match
+// want line by line: want is split by newlines, and each its line is a
+// regular expression, which must match corresponding line of output. It's
+// useful to check formatted stack traces, where paths and line numbers
+// differ between environments:
+//
+//	errtest.RequireFormat(t, err, "%+v", "read config\n"+
+//		`github.com/foo/bar.Load`+"\n"+
+//		`\t.+/bar/load.go:\d+`)
+func RequireFormat(t testing.TB, err error, format, want string) {
+	t.Helper()
+	got := fmt.Sprintf(format, err)
+	gotLines := strings.Split(got, "\n")
+	wantLines := strings.Split(want, "\n")
+
+	if len(wantLines) != len(gotLines) {
+		t.Fatalf("mismatched lines count:\nexpected: %v\nactual:   %v\noutput:\n%s", len(wantLines), len(gotLines), got)
+	}
+	for i, wantLine := range wantLines {
+		if !regexp.MustCompile(wantLine).MatchString(gotLines[i]) {
+			t.Fatalf("line %d doesn't match:\nline:    %q\npattern: %q\noutput:\n%s", i+1, gotLines[i], wantLine, got)
+		}
+	}
+}
+
+// RequireGolden requires err, formatted with format, to be equal to content
+// of golden file at path. If UpdateEnv is set, golden file is written
+// instead.
+//
+// Formatted stack traces contain absolute paths and line numbers, so golden
+// files fit best for formats without them, or in pair with deterministic
+// stack traces, see FreezeStacks.
+func RequireGolden(t testing.TB, err error, format, path string) {
+	t.Helper()
+	got := []byte(fmt.Sprintf(format, err))
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("reading golden file (run with %s=1 to create it): %v", UpdateEnv, readErr)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("output differs from golden file %s:\nexpected:\n%s\nactual:\n%s", path, want, got)
+	}
+}
+
+// FreezeStacks makes every stack trace, recorded by errors package until the
+// end of the test, consist of frames, so formatted output is the same in any
+// environment:
+//
+//	errtest.FreezeStacks(t, runtime.Frame{Function: "main.load", File: "/app/config.go", Line: 42})
+//	errtest.RequireGolden(t, load(), "%+v", "testdata/load.golden")
+//
+// Hook is global (see errors.SetCallersFunc), so tests, which freeze stacks,
+// must not run in parallel with others.
+func FreezeStacks(t testing.TB, frames ...runtime.Frame) {
+	t.Helper()
+	stack := errors.StackTraceFromRuntime(frames)
+	errors.SetCallersFunc(func(uint) errors.StackTrace { return stack })
+	t.Cleanup(func() { errors.SetCallersFunc(nil) })
+}
@@ -0,0 +1,117 @@
+package errtest_test
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/errtest"
+)
+
+// fakeT records failure of assertion, stopping its goroutine as t.FailNow
+// does.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failed = true
+	runtime.Goexit()
+}
+
+func fails(assertion func(t testing.TB)) bool {
+	t := new(fakeT)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assertion(t)
+	}()
+	<-done
+	return t.failed
+}
+
+func TestRequire(t *testing.T) {
+	err := errors.Wrap(&fs.PathError{Op: "open", Path: "config.yaml", Err: io.EOF}, "load config")
+
+	for _, tt := range []struct {
+		name      string
+		assertion func(t testing.TB)
+		fails     bool
+	}{
+		{"Is", func(t testing.TB) { errtest.RequireIs(t, err, io.EOF) }, false},
+		{"IsFails", func(t testing.TB) { errtest.RequireIs(t, err, io.ErrUnexpectedEOF) }, true},
+		{"NotIs", func(t testing.TB) { errtest.RequireNotIs(t, err, io.ErrUnexpectedEOF) }, false},
+		{"NotIsFails", func(t testing.TB) { errtest.RequireNotIs(t, err, io.EOF) }, true},
+		{"As", func(t testing.TB) {
+			var target *fs.PathError
+			errtest.RequireAs(t, err, &target)
+		}, false},
+		{"AsFails", func(t testing.TB) {
+			var target *os.SyscallError
+			errtest.RequireAs(t, err, &target)
+		}, true},
+		{"Message", func(t testing.TB) { errtest.RequireMessage(t, err, `^load config: open config\.yaml`) }, false},
+		{"MessageFails", func(t testing.TB) { errtest.RequireMessage(t, err, `^open`) }, true},
+		{"MessageNil", func(t testing.TB) { errtest.RequireMessage(t, nil, ``) }, true},
+		{"StackContains", func(t testing.TB) { errtest.RequireStackContains(t, err, "errtest_test.TestRequire") }, false},
+		{"StackContainsFull", func(t testing.TB) {
+			errtest.RequireStackContains(t, err, "github.com/quenbyako/errors/errtest_test.TestRequire")
+		}, false},
+		{"StackContainsFails", func(t testing.TB) { errtest.RequireStackContains(t, err, "errtest_test.Other") }, true},
+		{"Format", func(t testing.TB) {
+			errtest.RequireFormat(t, errors.NoStack("open config.yaml\n\tline 42"), "%v", `^open config\.yaml$`+"\n"+
+				`^\tline \d+$`)
+		}, false},
+		{"FormatFailsLine", func(t testing.TB) { errtest.RequireFormat(t, err, "%v", "load config: EOF") }, true},
+		{"FormatFailsCount", func(t testing.TB) { errtest.RequireFormat(t, err, "%v", "load config\nEOF") }, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.fails, fails(tt.assertion))
+		})
+	}
+}
+
+func TestRequireGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "error.golden")
+	err := errors.Wrap(io.EOF, "read")
+
+	require.True(t, fails(func(t testing.TB) { errtest.RequireGolden(t, err, "%v", path) }))
+
+	t.Setenv(errtest.UpdateEnv, "1")
+	errtest.RequireGolden(t, err, "%v", path)
+	got, readErr := os.ReadFile(path)
+	require.NoError(t, readErr)
+	require.Equal(t, "read: EOF", string(got))
+
+	t.Setenv(errtest.UpdateEnv, "")
+	errtest.RequireGolden(t, err, "%v", path)
+	require.True(t, fails(func(t testing.TB) { errtest.RequireGolden(t, err, "%s: %%v", path) }))
+}
+
+func TestFreezeStacks(t *testing.T) {
+	t.Run("frozen", func(t *testing.T) {
+		errtest.FreezeStacks(t,
+			runtime.Frame{Function: "main.load", File: "/app/config.go", Line: 42},
+			runtime.Frame{Function: "main.main", File: "/app/main.go", Line: 12},
+		)
+		err := errors.Wrap(errors.New("file not found"), "read config")
+		require.Equal(t, "read config: file not found\n"+
+			"main.load\n\t/app/config.go:42\n"+
+			"main.main\n\t/app/main.go:12\n"+
+			"main.load\n\t/app/config.go:42: read config\n",
+			fmt.Sprintf("%+v", err))
+	})
+
+	_, _, name := errors.Stack(errors.New("boom"))[0].FuncInfo()
+	require.Equal(t, "github.com/quenbyako/errors/errtest_test.TestFreezeStacks", name, "stacks must be restored after the test")
+}
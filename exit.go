@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// VerboseEnv is environment variable, which makes HandleMain print errors
+// with stack traces (as %+v), if it's set to non-empty value.
+const VerboseEnv = "ERRORS_VERBOSE"
+
+type withExitCode struct {
+	cause error
+	code  int
+}
+
+// WithExitCode annotates err with exit code of process, which can be
+// extracted later with ExitCode.
+// If err is nil, WithExitCode returns nil.
+func WithExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &withExitCode{cause: err, code: code}
+}
+
+func (w *withExitCode) Error() string   { return w.cause.Error() }
+func (w *withExitCode) Unwrap() error   { return w.cause }
+func (w *withExitCode) ExitCode() int   { return w.code }
+func (w *withExitCode) message() string { return "" }
+
+func (w *withExitCode) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withExitCode) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// ExitCode returns exit code of the outermost error in err's chain, which
+// implements ExitCode() int method (e.g. created by WithExitCode, or
+// *exec.ExitError of failed subprocess). If there is no such error, ExitCode
+// returns 1. If err is nil, ExitCode returns 0.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	for ; err != nil; err = Unwrap(err) {
+		if c, ok := err.(interface{ ExitCode() int }); ok {
+			return c.ExitCode()
+		}
+	}
+	return 1
+}
+
+// for tests
+var (
+	exit             = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// HandleMain is a final error handler of command line tools: if err is not
+// nil, it prints err to stderr and exits with its exit code (see ExitCode).
+// If VerboseEnv is set, err is printed with stack traces. If err is nil,
+// HandleMain does nothing.
+//
+//	func main() {
+//		errors.HandleMain(run())
+//	}
+func HandleMain(err error) {
+	if err == nil {
+		return
+	}
+	format := "%v\n"
+	if os.Getenv(VerboseEnv) != "" {
+		format = "%+v\n"
+	}
+	fmt.Fprintf(stderr, format, err)
+	exit(ExitCode(err))
+}
@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHandleMain(t *testing.T) {
+	var buf bytes.Buffer
+	code := -1
+	stderr, exit = &buf, func(c int) { code = c }
+	defer func() { stderr, exit = os.Stderr, os.Exit }()
+
+	HandleMain(nil)
+	if code != -1 || buf.Len() != 0 {
+		t.Fatalf("HandleMain(nil): got exit %d, output %q", code, buf.String())
+	}
+
+	t.Setenv(VerboseEnv, "")
+	HandleMain(WithExitCode(Wrap(io.EOF, "read"), 3))
+	if code != 3 || buf.String() != "read: EOF\n" {
+		t.Errorf("HandleMain: got exit %d, output %q", code, buf.String())
+	}
+
+	buf.Reset()
+	t.Setenv(VerboseEnv, "1")
+	HandleMain(New("failed"))
+	if code != 1 || !strings.HasPrefix(buf.String(), "failed\n"+pkgName+".TestHandleMain\n") {
+		t.Errorf("HandleMain verbose: got exit %d, output %q", code, buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+package errors_test
+
+import (
+	"io"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	require.Equal(t, 0, errors.ExitCode(nil))
+	require.Equal(t, 1, errors.ExitCode(io.EOF))
+	require.Nil(t, errors.WithExitCode(nil, 2))
+
+	err := errors.Wrap(errors.WithExitCode(io.EOF, 2), "read")
+	require.Equal(t, 2, errors.ExitCode(err))
+	require.Equal(t, 3, errors.ExitCode(errors.WithExitCode(err, 3)))
+	require.Equal(t, "read: EOF", err.Error())
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestExitCodeExecError(t *testing.T) {
+	sh, lookErr := exec.LookPath("sh")
+	if lookErr != nil {
+		t.Skip("sh is not available")
+	}
+	err := errors.Wrap(exec.Command(sh, "-c", "exit 7").Run(), "run")
+	require.Equal(t, 7, errors.ExitCode(err))
+}
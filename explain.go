@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Explain renders err's chain as a numbered list of human-readable causes,
+// from the outermost to the innermost, with structured fields and hints (see
+// WithHint) inline:
+//
+//  1. failed to sync user (user_id=42); 2. PUT /users/42 returned 503 (hint: retry later); 3. connection reset
+//
+// It's intended for CLI verbose output and support tickets rather than log
+// pipelines. If err is nil, Explain returns empty string.
+func Explain(err error) string {
+	var items []string
+	var pending []Field
+	var hints []string
+
+	for ; err != nil; err = Unwrap(err) {
+		if f, ok := err.(interface{ fields() []Field }); ok {
+			pending = append(pending, f.fields()...)
+		}
+		if h, ok := err.(*withHint); ok {
+			hints = append(hints, h.hint)
+		}
+		msg := ownMessage(err)
+		if msg == "" {
+			continue
+		}
+		items = append(items, strconv.Itoa(len(items)+1)+". "+msg+explainDetails(pending, hints))
+		pending, hints = nil, nil
+		if IsTruncated(err) {
+			// causes repeat messages, which were truncated
+			break
+		}
+	}
+	if len(items) > 0 {
+		items[len(items)-1] += explainDetails(pending, hints)
+	}
+
+	return strings.Join(items, "; ")
+}
+
+// explainDetails renders fields and hints of single item of Explain.
+func explainDetails(fields []Field, hints []string) string {
+	var res string
+	if len(fields) > 0 {
+		res += " (" + formatFields(fields) + ")"
+	}
+	for _, hint := range hints {
+		res += " (hint: " + hint + ")"
+	}
+	return res
+}
+
+// ownMessage returns message of the err itself, without messages of its
+// causes. Errors of this package report it directly, for foreign errors
+// message of the cause is trimmed from the end of error text, if possible.
+func ownMessage(err error) string {
+	if m, ok := err.(interface{ message() string }); ok {
+		return m.message()
+	}
+	return trimCause(err.Error(), Unwrap(err))
+}
+
+// trimCause trims message of the cause from the end of msg, if possible.
+func trimCause(msg string, cause error) string {
+	if cause == nil {
+		return msg
+	}
+	msg = strings.TrimSuffix(msg, cause.Error())
+	if trimmed := strings.TrimSuffix(msg, loadSeparator()); trimmed != msg {
+		return trimmed
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(msg, " "), ":")
+}
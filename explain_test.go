@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestExplain(t *testing.T) {
+	errUnavailable := errors.NewSentinel("PUT /users/42 returned 503")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{{
+		name: "nil",
+		err:  nil,
+		want: "",
+	}, {
+		name: "single",
+		err:  io.EOF,
+		want: "1. EOF",
+	}, {
+		name: "stack is skipped",
+		err:  errors.WithStack(errors.Wrap(io.EOF, "read")),
+		want: "1. read; 2. EOF",
+	}, {
+		name: "chain",
+		err: errors.Wrap(
+			fmt.Errorf("request: %w", errUnavailable.With("attempt", 3)),
+			"failed to sync user",
+		),
+		want: "1. failed to sync user; 2. request; 3. PUT /users/42 returned 503 (attempt=3)",
+	}, {
+		name: "hints",
+		err: errors.WithHint(errors.Wrap(
+			errors.WithHint(errors.WithField(io.ErrUnexpectedEOF, "host", "db"), "check network"),
+			"failed to sync user",
+		), "run with --retry"),
+		want: "1. failed to sync user (hint: run with --retry); 2. unexpected EOF (host=db) (hint: check network)",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Explain(tt.err))
+		})
+	}
+}
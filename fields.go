@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// Field is a key/value pair, attached to an error as structured data.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+type withField struct {
+	cause error
+	field Field
+}
+
+// WithField annotates err with a structured key/value pair, e.g. request or
+// user ID, which can be extracted later with Fields.
+// If err is nil, WithField returns nil.
+func WithField(err error, key string, value interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withField{
+		cause: err,
+		field: Field{Key: key, Value: value},
+	}
+}
+
+func (w *withField) Error() string   { return w.cause.Error() }
+func (w *withField) Unwrap() error   { return w.cause }
+func (w *withField) fields() []Field { return []Field{w.field} }
+func (w *withField) message() string { return "" }
+
+func (w *withField) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+// Fields returns all structured fields attached to errors in err's tree.
+// If the same key is attached several times, the outermost value wins.
+func Fields(err error) map[string]interface{} {
+	res := make(map[string]interface{})
+	walkTree(err, func(e error) {
+		f, ok := e.(interface{ fields() []Field })
+		if !ok {
+			return
+		}
+		for _, field := range f.fields() {
+			if _, ok := res[field.Key]; !ok {
+				res[field.Key] = field.Value
+			}
+		}
+	})
+	return res
+}
@@ -0,0 +1,63 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestWithFieldNil(t *testing.T) {
+	require.Nil(t, errors.WithField(nil, "key", "value"))
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{{
+		name: "nil",
+		err:  nil,
+		want: map[string]interface{}{},
+	}, {
+		name: "no fields",
+		err:  errors.Wrap(io.EOF, "read"),
+		want: map[string]interface{}{},
+	}, {
+		name: "nested",
+		err: errors.WithField(
+			errors.Wrap(errors.WithField(io.EOF, "user_id", 42), "read"),
+			"request_id", "abc",
+		),
+		want: map[string]interface{}{"user_id": 42, "request_id": "abc"},
+	}, {
+		name: "outer wins",
+		err:  errors.WithField(errors.WithField(io.EOF, "user_id", 42), "user_id", 43),
+		want: map[string]interface{}{"user_id": 43},
+	}, {
+		name: "joined",
+		err: errors.Join(
+			errors.WithField(io.EOF, "a", 1),
+			errors.WithField(io.EOF, "b", 2),
+		),
+		want: map[string]interface{}{"a": 1, "b": 2},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Fields(tt.err))
+		})
+	}
+}
+
+func TestWithFieldFormat(t *testing.T) {
+	err := errors.WithField(errors.WithMessage(io.EOF, "read"), "user_id", 42)
+
+	require.Equal(t, "read: EOF", err.Error())
+	require.Equal(t, "read: EOF", fmt.Sprintf("%+v", err))
+	require.Equal(t, "1. read (user_id=42); 2. EOF", errors.Explain(err))
+}
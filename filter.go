@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// FrameMatcher reports whether frame matches some condition.
+type FrameMatcher = func(Frame) bool
+
+// Filter returns a new stack trace with frames, for which keep returns true.
+func (st StackTrace) Filter(keep FrameMatcher) StackTrace {
+	res := make(StackTrace, 0, len(st))
+	for _, f := range st {
+		if keep(f) {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// TrimRuntime returns a new stack trace without frames of runtime and testing
+// packages, e.g. runtime.goexit, runtime.main and testing.tRunner.
+func (st StackTrace) TrimRuntime() StackTrace {
+	return st.Filter(func(f Frame) bool { return !isRuntimeFrame(f) })
+}
+
+// TrimBelow returns stack trace without frames below (i.e. callers of) the
+// first frame, which matches m. Matched frame is kept. If no frame matches,
+// stack trace is returned as is.
+func (st StackTrace) TrimBelow(m FrameMatcher) StackTrace {
+	for i, f := range st {
+		if m(f) {
+			return st[:i+1]
+		}
+	}
+	return st
+}
+
+// TrimAbove returns stack trace without frames above (i.e. called by) the
+// first frame, which matches m. Matched frame is kept. If no frame matches,
+// stack trace is returned as is.
+func (st StackTrace) TrimAbove(m FrameMatcher) StackTrace {
+	for i, f := range st {
+		if m(f) {
+			return st[i:]
+		}
+	}
+	return st
+}
+
+// MatchFunc returns matcher of frames, which function name (with package
+// path) has the prefix.
+func MatchFunc(prefix string) FrameMatcher {
+	return func(f Frame) bool {
+		_, _, name := f.FuncInfo()
+		return strings.HasPrefix(name, prefix)
+	}
+}
+
+func isRuntimeFrame(f Frame) bool {
+	_, _, name := f.FuncInfo()
+	return strings.HasPrefix(name, "runtime.") || strings.HasPrefix(name, "testing.")
+}
+
+type frameFilterHolder struct{ keep FrameMatcher }
+
+var frameFilter atomic.Value // frameFilterHolder
+
+// SetGlobalFrameFilter sets a filter, which is applied to every stack trace
+// when it's formatted. Frames, for which keep returns false, are not
+// printed. Recorded stack traces are not modified. Nil keep removes the
+// filter.
+func SetGlobalFrameFilter(keep FrameMatcher) {
+	frameFilter.Store(frameFilterHolder{keep: keep})
+}
+
+func globalFrameFilter() FrameMatcher {
+	h, _ := frameFilter.Load().(frameFilterHolder)
+	return h.keep
+}
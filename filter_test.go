@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func frameNames(st errors.StackTrace) []string {
+	res := make([]string, len(st))
+	for i, f := range st {
+		res[i] = funcName(f)
+	}
+	return res
+}
+
+func filterHelper() errors.StackTrace { return errors.Stack(errors.New("error")) }
+
+func TestStackTraceTrim(t *testing.T) {
+	st := filterHelper()
+	helper := errors.PkgName + ".filterHelper"
+	test := errors.PkgName + ".TestStackTraceTrim"
+
+	require.Equal(t, []string{helper, test, "testing.tRunner", "runtime.goexit"}, frameNames(st))
+	require.Equal(t, []string{helper, test}, frameNames(st.TrimRuntime()))
+	require.Equal(t, []string{helper, test}, frameNames(st.TrimBelow(errors.MatchFunc(test))))
+	require.Equal(t, []string{test, "testing.tRunner", "runtime.goexit"}, frameNames(st.TrimAbove(errors.MatchFunc(test))))
+	require.Equal(t, frameNames(st), frameNames(st.TrimAbove(errors.MatchFunc("nonexistent"))))
+	require.Equal(t, []string{"runtime.goexit"}, frameNames(st.Filter(errors.MatchFunc("runtime."))))
+}
+
+func TestSetGlobalFrameFilter(t *testing.T) {
+	errors.SetGlobalFrameFilter(errors.MatchFunc(errors.PkgName))
+	defer errors.SetGlobalFrameFilter(nil)
+
+	err := errors.New("error")
+	require.Regexp(t, `^error\n`+errors.PkgName+`\.TestSetGlobalFrameFilter\n\t[^\n]+\n$`, fmt.Sprintf("%+v", err))
+	require.Len(t, errors.Stack(err), 3)
+}
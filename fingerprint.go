@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// fingerprintFrames is a number of top frames of stack trace, which are used
+// in Fingerprint.
+const fingerprintFrames = 5
+
+// Equal reports whether st and other consist of the same frames. Frames are
+// compared by their function, file and line, so traces of the same call path
+// are equal, even if they were recorded by different processes.
+func (st StackTrace) Equal(other StackTrace) bool {
+	if len(st) != len(other) {
+		return false
+	}
+	for i := range st {
+		if st[i] == other[i] {
+			continue
+		}
+		file1, line1, name1 := st[i].FuncInfo()
+		file2, line2, name2 := other[i].FuncInfo()
+		if file1 != file2 || line1 != line2 || name1 != name2 {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns hash of st. Equal stack traces have equal hashes.
+func (st StackTrace) Hash() uint64 {
+	h := fnv.New64a()
+	for _, f := range st {
+		file, line, name := f.FuncInfo()
+		h.Write([]byte(name + "\x00" + file + "\x00" + strconv.Itoa(line) + "\n"))
+	}
+	return h.Sum64()
+}
+
+// Fingerprint returns an identifier of err's failure kind, which can be used
+// to group identical failures in logs and alerts. It's built from message
+// template of the root cause (format of NewT, message of Sentinel or Const,
+// otherwise the message itself) and functions of top frames of err's stack
+// trace. Line numbers are not used, so fingerprint survives unrelated code
+// changes.
+// If err is nil, Fingerprint returns empty string.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	h := fnv.New64a()
+	h.Write([]byte(messageTemplate(Cause(err)) + "\n"))
+
+	stack := Stack(err)
+	if len(stack) > fingerprintFrames {
+		stack = stack[:fingerprintFrames]
+	}
+	for _, f := range stack {
+		_, _, name := f.FuncInfo()
+		h.Write([]byte(name + "\n"))
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// messageTemplate returns message of err without variable parts, if they are
+// known.
+func messageTemplate(err error) string {
+	switch e := err.(type) {
+	case *templated:
+		return e.format
+	case *withParams:
+		return e.base.Error()
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,49 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestStackTraceEqual(t *testing.T) {
+	newStack := func() errors.StackTrace { return errors.Callers(0) }
+
+	a, b := newStack(), newStack()
+	other := errors.Callers(0)
+
+	require.True(t, a.Equal(b))
+	require.Equal(t, a.Hash(), b.Hash())
+	require.False(t, a.Equal(other))
+	require.NotEqual(t, a.Hash(), other.Hash())
+	require.False(t, a.Equal(a[1:]))
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	var remote errors.StackTrace
+	require.NoError(t, json.Unmarshal(data, &remote))
+	require.True(t, a.Equal(remote))
+	require.Equal(t, a.Hash(), remote.Hash())
+}
+
+func fingerprintHelper(id int) error {
+	return errors.Wrap(errors.NewT("user %d not found", errors.Arg("id", id)), "get user")
+}
+
+func TestFingerprint(t *testing.T) {
+	require.Equal(t, "", errors.Fingerprint(nil))
+
+	a := errors.Fingerprint(fingerprintHelper(1))
+	require.Len(t, a, 16)
+	require.Equal(t, a, errors.Fingerprint(fingerprintHelper(2)))
+	require.NotEqual(t, a, errors.Fingerprint(errors.NewT("user %d not found", errors.Arg("id", 1))))
+
+	sentinel := errors.NewSentinel("quota exceeded")
+	require.Equal(t,
+		errors.Fingerprint(sentinel.With("bytes", 1)),
+		errors.Fingerprint(sentinel.With("bytes", 2)),
+	)
+}
@@ -106,7 +106,9 @@ func TestFormatWrap(t *testing.T) {
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
-			"\t.+/src/runtime/asm_amd64.s:1371\n",
+			"\t.+/src/runtime/asm_amd64.s:1371\n" +
+			errors.PkgName + ".TestFormatWrap\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:101: error2\n",
 	}, {
 		errors.Wrap(io.EOF, "error"),
 		"%s",
@@ -120,7 +122,7 @@ func TestFormatWrap(t *testing.T) {
 		"%+v",
 		"error: EOF\n" +
 			errors.PkgName + ".TestFormatWrap\n" +
-			"\t.+/" + errors.PkgNameRaw + "/format_test.go:119\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:121\n" +
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
@@ -130,11 +132,13 @@ func TestFormatWrap(t *testing.T) {
 		"%+v",
 		"error2: error1: EOF\n" +
 			errors.PkgName + ".TestFormatWrap\n" +
-			"\t.+/" + errors.PkgNameRaw + "/format_test.go:129\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:131\n" +
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
-			"\t.+/src/runtime/asm_amd64.s:1371\n",
+			"\t.+/src/runtime/asm_amd64.s:1371\n" +
+			errors.PkgName + ".TestFormatWrap\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:131: error2\n",
 	}, {
 		errors.Wrap(errors.New("error with space"), "context"),
 		"%q",
@@ -166,7 +170,7 @@ func TestFormatWrapf(t *testing.T) {
 		"%+v",
 		"error2: EOF\n" +
 			errors.PkgName + ".TestFormatWrapf\n" +
-			"\t.+/" + errors.PkgNameRaw + "/format_test.go:165\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:169\n" +
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
@@ -184,11 +188,13 @@ func TestFormatWrapf(t *testing.T) {
 		"%+v",
 		"error\n" +
 			errors.PkgName + ".TestFormatWrapf\n" +
-			"\t.+/" + errors.PkgNameRaw + "/format_test.go:183\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:187\n" +
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
-			"\t.+/src/runtime/asm_amd64.s:1371\n",
+			"\t.+/src/runtime/asm_amd64.s:1371\n" +
+			errors.PkgName + ".TestFormatWrapf\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:187: error2\n",
 	}}
 
 	for _, tt := range tests {
@@ -210,7 +216,7 @@ func TestFormatWrappedNew(t *testing.T) {
 			errors.PkgName + ".wrappedNew\n" +
 			"\t.+/" + errors.PkgNameRaw + "/format_test.go:16\n" +
 			errors.PkgName + ".TestFormatWrappedNew\n" +
-			"\t.+/" + errors.PkgNameRaw + "/format_test.go:207\n" +
+			"\t.+/" + errors.PkgNameRaw + "/format_test.go:213\n" +
 			"testing.tRunner\n" +
 			"\t.+/src/testing/testing.go:1194\n" +
 			"runtime.goexit\n" +
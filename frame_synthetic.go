@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"strconv"
+	"sync"
+)
+
+// syntheticBit marks frames, which are not backed by program counter of
+// current binary, but describe frames received from outside (e.g. parsed
+// from another service's error). Real program counters never have highest
+// bit set.
+const syntheticBit = uintptr(1) << (strconv.IntSize - 1)
+
+// Synthetic frame keeps index of its slot in the registry in lower
+// syntheticSlotBits bits and identifier of the registration above them, so
+// frames of evicted registrations are not confused with new ones.
+const (
+	syntheticSlotBits = 16
+	syntheticCapacity = 1 << syntheticSlotBits
+	syntheticSlotMask = syntheticCapacity - 1
+	syntheticIDMask   = ^syntheticBit >> syntheticSlotBits
+)
+
+type frameInfo struct {
+	file string
+	line int
+	name string
+}
+
+type syntheticSlot struct {
+	info frameInfo
+	id   uintptr
+}
+
+// synthetic is a registry of synthetic frames. Since same frames are usually
+// received many times, each frame is registered only once. Frames are
+// received from untrusted input, so registry is bounded: when it's full, the
+// oldest registration is evicted, and its frames are formatted as unknown
+// ones.
+var synthetic struct {
+	sync.RWMutex
+	slots  []syntheticSlot
+	next   int
+	lastID uintptr
+	index  map[frameInfo]Frame
+}
+
+// syntheticFrame returns a frame, which formats as function name located
+// in file at line.
+func syntheticFrame(file string, line int, name string) Frame {
+	info := frameInfo{file: file, line: line, name: name}
+
+	synthetic.RLock()
+	f, ok := synthetic.index[info]
+	synthetic.RUnlock()
+	if ok {
+		return f
+	}
+
+	synthetic.Lock()
+	defer synthetic.Unlock()
+	if f, ok := synthetic.index[info]; ok {
+		return f
+	}
+	if synthetic.index == nil {
+		synthetic.index = make(map[frameInfo]Frame)
+	}
+
+	synthetic.lastID = (synthetic.lastID + 1) & syntheticIDMask
+	if synthetic.lastID == 0 {
+		synthetic.lastID = 1
+	}
+	slot := syntheticSlot{info: info, id: synthetic.lastID}
+	i := synthetic.next
+	if i < len(synthetic.slots) {
+		delete(synthetic.index, synthetic.slots[i].info)
+		synthetic.slots[i] = slot
+	} else {
+		synthetic.slots = append(synthetic.slots, slot)
+	}
+	synthetic.next = (i + 1) & syntheticSlotMask
+
+	f = Frame(syntheticBit | slot.id<<syntheticSlotBits | uintptr(i))
+	synthetic.index[info] = f
+	return f
+}
+
+func (f Frame) isSynthetic() bool { return uintptr(f)&syntheticBit != 0 }
+
+func (f Frame) syntheticInfo() (file string, line int, name string) {
+	i := int(uintptr(f) & syntheticSlotMask)
+	id := uintptr(f) &^ syntheticBit >> syntheticSlotBits
+
+	synthetic.RLock()
+	defer synthetic.RUnlock()
+	if i >= len(synthetic.slots) || synthetic.slots[i].id != id {
+		return unknown, 0, unknown
+	}
+	info := synthetic.slots[i].info
+	return info.file, info.line, info.name
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticFrameEviction(t *testing.T) {
+	first := syntheticFrame("/src/evicted.go", 1, "pkg.Evicted")
+	file, line, name := first.FuncInfo()
+	require.Equal(t, "/src/evicted.go", file)
+	require.Equal(t, 1, line)
+	require.Equal(t, "pkg.Evicted", name)
+	require.Equal(t, first, syntheticFrame("/src/evicted.go", 1, "pkg.Evicted"))
+
+	var last Frame
+	for i := 0; i < syntheticCapacity; i++ {
+		last = syntheticFrame("/src/filler.go", i, "pkg.Filler"+strconv.Itoa(i))
+	}
+
+	synthetic.RLock()
+	require.LessOrEqual(t, len(synthetic.slots), syntheticCapacity)
+	require.LessOrEqual(t, len(synthetic.index), syntheticCapacity)
+	synthetic.RUnlock()
+
+	// evicted frame must not be confused with the frame in its slot
+	file, line, name = first.FuncInfo()
+	require.Equal(t, unknown, file)
+	require.Equal(t, 0, line)
+	require.Equal(t, unknown, name)
+
+	_, line, name = last.FuncInfo()
+	require.Equal(t, syntheticCapacity-1, line)
+	require.Equal(t, "pkg.Filler"+strconv.Itoa(syntheticCapacity-1), name)
+
+	again := syntheticFrame("/src/evicted.go", 1, "pkg.Evicted")
+	require.NotEqual(t, first, again)
+	_, _, name = again.FuncInfo()
+	require.Equal(t, "pkg.Evicted", name)
+}
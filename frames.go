@@ -0,0 +1,135 @@
+package errors
+
+import "runtime"
+
+// PCs returns program counters of st exactly as runtime.Callers returns them,
+// so they may be passed directly to runtime.CallersFrames. Returned slice is
+// a copy, so it may be modified.
+//
+// Synthetic frames (e.g. decoded from JSON) are not backed by program
+// counters of current binary and have no meaning for runtime package.
+func (st StackTrace) PCs() []uintptr {
+	if len(st) == 0 {
+		return nil
+	}
+	pcs := make([]uintptr, len(st))
+	for i, f := range st {
+		pcs[i] = uintptr(f)
+	}
+	return pcs
+}
+
+// StackTraceFromPCs returns stack trace of program counters, returned by
+// runtime.Callers, e.g. recorded by another package. pcs are copied, so they
+// may be reused after the call.
+func StackTraceFromPCs(pcs []uintptr) StackTrace {
+	if len(pcs) == 0 {
+		return nil
+	}
+	res := make(StackTrace, len(pcs))
+	for i, pc := range pcs {
+		res[i] = Frame(pc)
+	}
+	return res
+}
+
+// Frames is an iterator over frames of StackTrace, see StackTrace.Frames.
+// Symbolization of each frame is deferred until FuncInfo is called.
+//
+// Frames doesn't change the cost of capturing stack traces: each error
+// already allocates a single exactly sized StackTrace, which is the same
+// backing array of program counters. To cut allocations of high error rates
+// further, see SetStackArena.
+type Frames struct {
+	st StackTrace
+	i  int
+}
+
+// Frames returns iterator over frames of st from innermost to outermost:
+//
+//	for frames := st.Frames(); frames.Next(); {
+//		file, line, name := frames.FuncInfo()
+//		...
+//	}
+func (st StackTrace) Frames() Frames { return Frames{st: st, i: -1} }
+
+// Next advances iterator to the next frame and reports whether there is one.
+func (f *Frames) Next() bool {
+	if f.i >= len(f.st) {
+		return false
+	}
+	f.i++
+	return f.i < len(f.st)
+}
+
+// Frame returns current frame.
+func (f *Frames) Frame() Frame { return f.st[f.i] }
+
+// FuncInfo returns file, line and function name of current frame, see
+// Frame.FuncInfo.
+func (f *Frames) FuncInfo() (file string, line int, name string) {
+	return f.st[f.i].FuncInfo()
+}
+
+// Func returns runtime function, which contains f. Synthetic frames (e.g.
+// decoded from JSON) have no function in current binary, so Func returns nil
+// for them.
+func (f Frame) Func() *runtime.Func {
+	if f.isSynthetic() {
+		return nil
+	}
+	return runtime.FuncForPC(f.pc())
+}
+
+// Runtime returns f as runtime.Frame, in the same form as
+// runtime.CallersFrames returns it. Synthetic frames have only Function,
+// File and Line fields set.
+func (f Frame) Runtime() runtime.Frame {
+	file, line, name := f.FuncInfo()
+	if f.isSynthetic() {
+		return runtime.Frame{Function: name, File: file, Line: line}
+	}
+	res := runtime.Frame{PC: f.pc(), Function: name, File: file, Line: line}
+	if fn := f.Func(); fn != nil {
+		res.Func = fn
+		res.Entry = fn.Entry()
+	}
+	return res
+}
+
+// FrameFromRuntime converts frame, returned by runtime.CallersFrames, into
+// Frame. If frame has no program counter, synthetic frame with its function,
+// file and line is returned.
+func FrameFromRuntime(frame runtime.Frame) Frame {
+	if frame.PC == 0 {
+		return syntheticFrame(frame.File, frame.Line, frame.Function)
+	}
+	return Frame(frame.PC + 1)
+}
+
+// RuntimeFrames converts st into runtime frames, one per frame of st (see
+// Frame.Runtime). Unlike runtime.CallersFrames, inlined calls are not
+// expanded, so frames correspond to st one to one.
+func (st StackTrace) RuntimeFrames() []runtime.Frame {
+	if len(st) == 0 {
+		return nil
+	}
+	res := make([]runtime.Frame, len(st))
+	for i, f := range st {
+		res[i] = f.Runtime()
+	}
+	return res
+}
+
+// StackTraceFromRuntime converts runtime frames into StackTrace, see
+// FrameFromRuntime.
+func StackTraceFromRuntime(frames []runtime.Frame) StackTrace {
+	if len(frames) == 0 {
+		return nil
+	}
+	res := make(StackTrace, len(frames))
+	for i, frame := range frames {
+		res[i] = FrameFromRuntime(frame)
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+package errors_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestStackTracePCs(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+	pcs := st.PCs()
+	if len(pcs) != len(st) {
+		t.Fatalf("got %d pcs, want %d", len(pcs), len(st))
+	}
+	frames := runtime.CallersFrames(pcs)
+	frame, _ := frames.Next()
+	if want := errors.PkgName + ".TestStackTracePCs"; frame.Function != want {
+		t.Errorf("got %q, want %q", frame.Function, want)
+	}
+
+	if pcs := errors.StackTrace(nil).PCs(); pcs != nil {
+		t.Errorf("nil stack: got %v, want nil", pcs)
+	}
+}
+
+func TestStackTraceFrames(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+
+	var i int
+	for frames := st.Frames(); frames.Next(); i++ {
+		if frames.Frame() != st[i] {
+			t.Errorf("frame %d: got %v, want %v", i, frames.Frame(), st[i])
+		}
+		file, line, name := frames.FuncInfo()
+		wantFile, wantLine, wantName := st[i].FuncInfo()
+		if file != wantFile || line != wantLine || name != wantName {
+			t.Errorf("frame %d: got %s:%d %s, want %s:%d %s", i, file, line, name, wantFile, wantLine, wantName)
+		}
+	}
+	if i != len(st) {
+		t.Errorf("got %d frames, want %d", i, len(st))
+	}
+
+	frames := errors.StackTrace(nil).Frames()
+	if frames.Next() || frames.Next() {
+		t.Errorf("nil stack: unexpected frame")
+	}
+}
+
+func TestFrameRuntime(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+
+	frame := st[0].Runtime()
+	if want := errors.PkgName + ".TestFrameRuntime"; frame.Function != want || frame.Func == nil || frame.Func.Name() != want {
+		t.Errorf("got %q, want %q", frame.Function, want)
+	}
+	pcFrames := runtime.CallersFrames(st.PCs()[:1])
+	want, _ := pcFrames.Next()
+	if frame.PC != want.PC || frame.File != want.File || frame.Line != want.Line || frame.Entry != want.Entry {
+		t.Errorf("got %+v, want %+v", frame, want)
+	}
+	if got := errors.FrameFromRuntime(want); got != st[0] {
+		t.Errorf("FrameFromRuntime: got %v, want %v", got, st[0])
+	}
+
+	frames := st.RuntimeFrames()
+	if len(frames) != len(st) {
+		t.Fatalf("got %d frames, want %d", len(frames), len(st))
+	}
+	back := errors.StackTraceFromRuntime(frames)
+	for i := range st {
+		if back[i] != st[i] {
+			t.Errorf("frame %d: got %v, want %v", i, back[i], st[i])
+		}
+	}
+	if errors.StackTrace(nil).RuntimeFrames() != nil || errors.StackTraceFromRuntime(nil) != nil {
+		t.Error("nil stack must convert to nil")
+	}
+}
+
+func TestFrameFromRuntimeSynthetic(t *testing.T) {
+	f := errors.FrameFromRuntime(runtime.Frame{Function: "remote.Func", File: "/src/remote.go", Line: 42})
+	if f.Func() != nil {
+		t.Error("synthetic frame must have no function")
+	}
+	file, line, name := f.FuncInfo()
+	if file != "/src/remote.go" || line != 42 || name != "remote.Func" {
+		t.Errorf("got %s:%d %s", file, line, name)
+	}
+	if rf := f.Runtime(); rf.PC != 0 || rf.Function != "remote.Func" || rf.Line != 42 {
+		t.Errorf("got %+v", rf)
+	}
+}
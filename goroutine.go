@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"io"
+	"runtime"
+	"strconv"
+)
+
+// FormatGoroutine writes st to w in exactly the same style as Go runtime
+// prints goroutines in panics and runtime.Stack dumps:
+//
+//	main.handle(...)
+//		/src/main.go:42 +0x1d
+//	main.main()
+//		/src/main.go:17 +0x25
+//
+// so tools, which understand panic output (log parsers, crash reporters,
+// "jump to file" in IDEs), can process it unmodified. Arguments of calls are
+// not recorded, so they are always printed as "(...)". Goroutine header is
+// not printed, callers may write it by themselves, if tool requires it.
+func (st StackTrace) FormatGoroutine(w io.Writer) error {
+	buf := make([]byte, 0, 128)
+	for _, f := range st {
+		file, line, name := f.FuncInfo()
+
+		buf = append(buf[:0], name...)
+		buf = append(buf, "(...)\n\t"...)
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(line), 10)
+		if offset, ok := f.entryOffset(); ok {
+			buf = append(buf, " +0x"...)
+			buf = strconv.AppendUint(buf, uint64(offset), 16)
+		}
+		buf = append(buf, '\n')
+
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entryOffset returns offset of frame's return address from entry of its
+// function, as runtime prints it in tracebacks.
+func (f Frame) entryOffset() (uintptr, bool) {
+	if f == 0 || f.isSynthetic() {
+		return 0, false
+	}
+	fn := runtime.FuncForPC(f.pc())
+	if fn == nil {
+		return 0, false
+	}
+	return uintptr(f) - fn.Entry(), true
+}
@@ -0,0 +1,45 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func stackAndDump() (errors.StackTrace, string) {
+	return errors.Callers(0), string(debug.Stack())
+}
+
+func TestFormatGoroutine(t *testing.T) {
+	st, dump := stackAndDump()
+
+	var buf bytes.Buffer
+	require.NoError(t, st.FormatGoroutine(&buf))
+	require.Equal(t, buf.String(), fmt.Sprintf("%#v", st))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2*len(st))
+	require.Equal(t, errors.PkgName+".stackAndDump(...)", lines[0])
+	require.Regexp(t, `^\t.+/goroutine_test\.go:\d+ \+0x[0-9a-f]+$`, lines[1])
+
+	// callers of stackAndDump share return addresses with runtime dump, which
+	// omits runtime.goexit
+	for i := 3; i < len(lines)-2; i += 2 {
+		require.Contains(t, dump, lines[i]+"\n")
+	}
+}
+
+func TestFormatGoroutineSynthetic(t *testing.T) {
+	var f errors.Frame
+	require.NoError(t, f.UnmarshalText([]byte("github.com/foo/bar.Baz /src/bar/baz.go:42")))
+
+	var buf bytes.Buffer
+	require.NoError(t, errors.StackTrace{f}.FormatGoroutine(&buf))
+	require.Equal(t, "github.com/foo/bar.Baz(...)\n\t/src/bar/baz.go:42\n", buf.String())
+}
@@ -0,0 +1,83 @@
+package errors
+
+import "sync"
+
+// Group accumulates errors, e.g. failures across a loop or fan-out, and
+// returns them as a single multi-error (see Join). Zero value is ready to
+// use, but is not safe for concurrent use, see WithLock.
+type Group struct {
+	mu   *sync.Mutex
+	errs []error
+}
+
+// GroupOption configures Group.
+type GroupOption func(*Group)
+
+// WithLock makes Group safe for concurrent use.
+func WithLock() GroupOption {
+	return func(g *Group) { g.mu = new(sync.Mutex) }
+}
+
+// NewGroup returns a new Group configured with options.
+func NewGroup(opts ...GroupOption) *Group {
+	g := new(Group)
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+func (g *Group) lock() {
+	if g.mu != nil {
+		g.mu.Lock()
+	}
+}
+
+func (g *Group) unlock() {
+	if g.mu != nil {
+		g.mu.Unlock()
+	}
+}
+
+// Add appends err to the group. If err doesn't have a stack trace, Add also
+// records the stack trace at the point it was called. If err is nil, Add
+// does nothing.
+func (g *Group) Add(err error) {
+	if err == nil {
+		return
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	g.lock()
+	g.errs = append(g.errs, err)
+	g.unlock()
+}
+
+// Len returns number of accumulated errors.
+func (g *Group) Len() int {
+	g.lock()
+	defer g.unlock()
+	return len(g.errs)
+}
+
+// Errors returns copy of accumulated errors.
+func (g *Group) Errors() []error {
+	g.lock()
+	defer g.unlock()
+	if len(g.errs) == 0 {
+		return nil
+	}
+	return append([]error(nil), g.errs...)
+}
+
+// Err returns accumulated errors as a single error. If group contains only
+// one error, it's returned as is, otherwise errors are joined with Join.
+// If group is empty, Err returns nil.
+func (g *Group) Err() error {
+	errs := g.Errors()
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return Join(errs...)
+}
@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestGroup(t *testing.T) {
+	var g errors.Group
+	require.NoError(t, g.Err())
+	require.Zero(t, g.Len())
+	require.Nil(t, g.Errors())
+
+	g.Add(nil)
+	g.Add(io.EOF)
+	require.Equal(t, 1, g.Len())
+	require.True(t, stderrors.Is(g.Err(), io.EOF))
+	require.Equal(t, errors.PkgName+".TestGroup", funcName(errors.Stack(g.Err())[0]))
+
+	g.Add(errors.New("second"))
+	require.Equal(t, 2, g.Len())
+	require.Len(t, g.Errors(), 2)
+	require.Equal(t, "EOF\nsecond", g.Err().Error())
+	require.Len(t, errors.Stacks(g.Err()), 2)
+	require.Regexp(t, `^2 errors occurred:\n  \[1\] EOF\n`, fmt.Sprintf("%+v", g.Err()))
+}
+
+func TestGroupWithLock(t *testing.T) {
+	g := errors.NewGroup(errors.WithLock())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g.Add(errors.Errorf("error %d", i))
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, 100, g.Len())
+	require.Len(t, errors.Joined(g.Err()), 100)
+}
@@ -0,0 +1,123 @@
+package grpcstatus
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/quenbyako/errors"
+)
+
+// Option configures server interceptors.
+type Option func(*serverOptions)
+
+type serverOptions struct {
+	fullChain bool
+}
+
+// WithFullChain makes server interceptors send the whole error chain to
+// clients (see Status): original messages, fields and stack traces with
+// absolute paths. It must be used only between trusted services, since
+// internal details (SQL queries, file paths, etc.) leave the service.
+func WithFullChain() Option {
+	return func(o *serverOptions) { o.fullChain = true }
+}
+
+// UnaryServerInterceptor returns server interceptor, which passes every
+// error of unary handlers through remapper and converts it into gRPC status,
+// so clients receive proper status code (see Code). By default status
+// carries only public message of error (see errors.Public, message can be
+// set by errors.WithSafeMessage), so internal details don't leak. Messages
+// of gRPC statuses, returned by handlers, and of errors, returned by
+// remapper, are considered public and sent as is. With WithFullChain,
+// status contains the whole error chain, which UnaryClientInterceptor
+// reconstructs. Nil remapper doesn't remap errors.
+func UnaryServerInterceptor(remapper *errors.Remapper, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newServerOptions(opts)
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, o.toStatus(remapper, err)
+	}
+}
+
+// StreamServerInterceptor is the same as UnaryServerInterceptor, but for
+// streaming handlers.
+func StreamServerInterceptor(remapper *errors.Remapper, opts ...Option) grpc.StreamServerInterceptor {
+	o := newServerOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return o.toStatus(remapper, handler(srv, ss))
+	}
+}
+
+func newServerOptions(opts []Option) *serverOptions {
+	o := &serverOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *serverOptions) toStatus(remapper *errors.Remapper, err error) error {
+	if err == nil {
+		return nil
+	}
+	public := false
+	if remapper != nil {
+		if out := remapper.Remap(err); out != err {
+			// remapper chooses errors for clients, so their messages are
+			// public
+			err, public = out, true
+		}
+	}
+	if o.fullChain {
+		return Status(err).Err()
+	}
+	return status.New(Code(err), publicMessage(err, public)).Err()
+}
+
+// publicMessage returns message of err, which can be sent to clients:
+// message of gRPC status in err's chain (statuses are created for clients),
+// message of err itself, if it's public, or errors.Public otherwise.
+func publicMessage(err error, public bool) string {
+	var s interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &s) {
+		return s.GRPCStatus().Message()
+	}
+	if public {
+		return err.Error()
+	}
+	return errors.Public(err)
+}
+
+// UnaryClientInterceptor returns client interceptor, which converts gRPC
+// statuses, returned by calls, back into errors of this package (see
+// FromError), so Stack, Cause, Fields and other functions of errors package
+// work with errors of remote service.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor is the same as UnaryClientInterceptor, but for
+// streaming calls: errors of opening the stream and of its methods are
+// converted.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return &clientStream{ClientStream: cs}, nil
+	}
+}
+
+// clientStream converts errors of wrapped stream with FromError.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m interface{}) error { return FromError(s.ClientStream.SendMsg(m)) }
+func (s *clientStream) RecvMsg(m interface{}) error { return FromError(s.ClientStream.RecvMsg(m)) }
+func (s *clientStream) CloseSend() error            { return FromError(s.ClientStream.CloseSend()) }
@@ -0,0 +1,127 @@
+package grpcstatus_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/grpcstatus"
+)
+
+func TestUnaryInterceptors(t *testing.T) {
+	errNotFound := errors.WithKind(errors.New("user not found"), errors.KindNotFound)
+	server := grpcstatus.UnaryServerInterceptor(errors.NewRemapper(errors.IsRemapper(io.EOF, errNotFound)), grpcstatus.WithFullChain())
+
+	resp, err := server(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "resp", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "resp", resp)
+
+	_, serverErr := server(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.Wrap(io.EOF, "query")
+	})
+	st, ok := status.FromError(serverErr)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Equal(t, "user not found", st.Message())
+
+	client := grpcstatus.UnaryClientInterceptor()
+	err = client(context.Background(), "/svc/Get", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return serverErr
+	})
+	require.Error(t, err)
+	require.Equal(t, "user not found", err.Error())
+	require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+	require.NotNil(t, errors.Stack(err))
+}
+
+func TestUnaryServerInterceptorSanitized(t *testing.T) {
+	server := grpcstatus.UnaryServerInterceptor(nil)
+
+	_, err := server(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithKind(errors.Wrap(io.EOF, "SELECT * FROM users"), errors.KindNotFound)
+	})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Equal(t, errors.DefaultPublicMessage, st.Message())
+	require.Empty(t, st.Details())
+
+	_, err = server(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithSafeMessage(errors.Wrap(io.EOF, "SELECT * FROM users"), "user not found")
+	})
+	st, _ = status.FromError(err)
+	require.Equal(t, "user not found", st.Message())
+	require.Empty(t, st.Details())
+}
+
+func TestUnaryServerInterceptorPublic(t *testing.T) {
+	errNotFound := errors.WithKind(errors.New("user not found"), errors.KindNotFound)
+	server := grpcstatus.UnaryServerInterceptor(errors.NewRemapper(errors.IsRemapper(io.EOF, errNotFound)))
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{"status", status.Error(codes.NotFound, "user not found"), codes.NotFound, "user not found"},
+		{"wrapped status", errors.Wrap(status.Error(codes.AlreadyExists, "user exists"), "SELECT * FROM users"), codes.AlreadyExists, "user exists"},
+		{"remapped", errors.Wrap(io.EOF, "SELECT * FROM users"), codes.NotFound, "user not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			})
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.wantCode, st.Code())
+			require.Equal(t, tt.wantMsg, st.Message())
+			require.Empty(t, st.Details())
+		})
+	}
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *fakeClientStream) RecvMsg(interface{}) error { return s.err }
+
+func TestStreamInterceptors(t *testing.T) {
+	server := grpcstatus.StreamServerInterceptor(nil, grpcstatus.WithFullChain())
+	serverErr := server(nil, nil, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		return errors.WithKind(errors.New("denied"), errors.KindForbidden)
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(serverErr))
+	require.NoError(t, server(nil, nil, &grpc.StreamServerInfo{}, func(interface{}, grpc.ServerStream) error { return nil }))
+
+	client := grpcstatus.StreamClientInterceptor()
+	open := func(err error) grpc.ClientStream {
+		cs, openErr := client(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Watch", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &fakeClientStream{err: err}, nil
+		})
+		require.NoError(t, openErr)
+		return cs
+	}
+
+	err := open(serverErr).RecvMsg(nil)
+	require.Equal(t, "denied", err.Error())
+	require.NotNil(t, errors.Stack(err))
+	require.Equal(t, io.EOF, open(io.EOF).RecvMsg(nil))
+
+	_, err = client(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Watch", func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, serverErr
+	})
+	require.Equal(t, codes.PermissionDenied, grpcstatus.Code(err))
+}
@@ -0,0 +1,136 @@
+// Package grpcstatus converts errors of github.com/quenbyako/errors package
+// into gRPC statuses and back, keeping message chain, error code and stack
+// traces.
+//
+// Full error chain is encoded into errdetails.DebugInfo status detail, so
+// errors received from another service still support Stack, Cause, Fields
+// and other functions of errors package.
+//
+// Server and client interceptors apply the conversion to every call, so
+// handlers just return errors. Server interceptors send only public messages
+// of errors by default, full chains are sent with WithFullChain option, and
+// clients receive reconstructed chains.
+package grpcstatus
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quenbyako/errors"
+)
+
+// maxCode is the largest canonical gRPC code.
+const maxCode = codes.Unauthenticated
+
+// Status converts err into gRPC status. Status code is taken from gRPC status
+// in err's chain, if any, from kind of err (see errors.KindOf), or from error
+// code (see errors.Code), if it's a valid gRPC code. Otherwise codes.Unknown
+// is used.
+// If err is nil, Status returns nil.
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	st := status.New(Code(err), err.Error())
+
+	info := &errdetails.DebugInfo{}
+	for _, f := range errors.Stack(err) {
+		text, _ := f.MarshalText()
+		info.StackEntries = append(info.StackEntries, string(text))
+	}
+	if chain, jerr := errors.ToJSON(err); jerr == nil {
+		info.Detail = string(chain)
+	}
+
+	if withDetails, derr := st.WithDetails(info); derr == nil {
+		st = withDetails
+	}
+	return st
+}
+
+// Code returns gRPC code of err. See Status for details.
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	var s interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &s) {
+		return s.GRPCStatus().Code()
+	}
+	if kind := errors.KindOf(err); kind != errors.KindUnknown {
+		return codes.Code(kind.GRPCCode())
+	}
+	if c, ok := errors.Code(err); ok && c >= 0 && codes.Code(c) <= maxCode {
+		return codes.Code(c)
+	}
+	return codes.Unknown
+}
+
+// Error converts gRPC status into an error. If status was created by
+// Status, original error chain is reconstructed. Returned error also
+// implements GRPCStatus method, so it can be passed through gRPC again.
+// If st is nil or has codes.OK code, Error returns nil.
+func Error(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var cause error
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.DebugInfo); ok && info.Detail != "" {
+			cause, _ = errors.FromJSON([]byte(info.Detail))
+		}
+	}
+	if cause == nil {
+		cause = errors.NoStack(st.Message())
+	}
+
+	return &statusError{st: st, cause: cause}
+}
+
+// FromError converts an error received by gRPC client into an error with
+// reconstructed chain (see Error). If err doesn't contain gRPC status, it's
+// returned as is.
+func FromError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return Error(st)
+}
+
+// statusError is an error reconstructed from gRPC status.
+type statusError struct {
+	st    *status.Status
+	cause error
+}
+
+func (e *statusError) Error() string              { return e.cause.Error() }
+func (e *statusError) Unwrap() error              { return e.cause }
+func (e *statusError) GRPCStatus() *status.Status { return e.st }
+
+// Is reports whether target is a gRPC status error with the same code.
+func (e *statusError) Is(target error) bool {
+	t, ok := target.(interface{ GRPCStatus() *status.Status })
+	return ok && t.GRPCStatus().Code() == e.st.Code()
+}
+
+func (e *statusError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%+v", e.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
@@ -0,0 +1,64 @@
+package grpcstatus_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/grpcstatus"
+)
+
+func TestStatusNil(t *testing.T) {
+	require.Nil(t, grpcstatus.Status(nil))
+	require.Nil(t, grpcstatus.Error(nil))
+	require.Nil(t, grpcstatus.Error(status.New(codes.OK, "")))
+}
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"nil", nil, codes.OK},
+		{"plain", io.EOF, codes.Unknown},
+		{"error code", errors.WithCode(io.EOF, int(codes.NotFound)), codes.NotFound},
+		{"invalid error code", errors.WithCode(io.EOF, 404), codes.Unknown},
+		{"status", errors.Wrap(status.Error(codes.Aborted, "tx"), "commit"), codes.Aborted},
+		{"kind", errors.WithKind(io.EOF, errors.KindForbidden), codes.PermissionDenied},
+		{"kind over code", errors.WithKind(errors.WithCode(io.EOF, int(codes.Aborted)), errors.KindNotFound), codes.NotFound},
+		{"canceled", errors.Wrap(context.Canceled, "call"), codes.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, grpcstatus.Code(tt.err))
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	orig := errors.WithField(
+		errors.WithCode(errors.Wrap(errors.New("no rows"), "get user"), int(codes.NotFound)),
+		"user_id", 42,
+	)
+
+	st := grpcstatus.Status(orig)
+	require.Equal(t, codes.NotFound, st.Code())
+	require.Equal(t, "get user: no rows", st.Message())
+
+	err := grpcstatus.FromError(st.Err())
+	require.Equal(t, orig.Error(), err.Error())
+	require.Equal(t, "no rows", errors.Cause(err).Error())
+	require.Equal(t, fmt.Sprintf("%+v", errors.Stack(orig)), fmt.Sprintf("%+v", errors.Stack(err)))
+	require.Equal(t, map[string]interface{}{"user_id": float64(42)}, errors.Fields(err))
+	require.True(t, stderrors.Is(err, status.Error(codes.NotFound, "")))
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
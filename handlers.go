@@ -2,7 +2,10 @@ package errors
 
 import (
 	"fmt"
+	"io"
 	"reflect"
+	"regexp"
+	"strings"
 )
 
 // ErrConverter is a function which converts one type of error into a different one. You can use it to convert
@@ -34,6 +37,134 @@ func Remap(err error, remappers []ErrRemapperFunc) error {
 	return err
 }
 
+// Remapper is an ordered set of remapping rules. Unlike Remap, which matches
+// only outermost error, Remapper matches each rule against every error in
+// the chain (the same way, as Is and As do), so wrapped errors are remapped
+// too. Rules are checked in order, first matched rule wins.
+//
+// Zero value is ready to use. Remapper is not safe for concurrent
+// modification, but Remap can be called concurrently, if rules are not
+// modified anymore.
+type Remapper struct {
+	rules        []ErrRemapperFunc
+	keepOriginal bool
+	debug        bool
+}
+
+// NewRemapper returns Remapper with provided rules.
+func NewRemapper(rules ...ErrRemapperFunc) *Remapper {
+	return &Remapper{rules: rules}
+}
+
+// Add appends rules to the end of rule set. It returns r to allow chaining.
+func (r *Remapper) Add(rules ...ErrRemapperFunc) *Remapper {
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// Prepend inserts rules to the beginning of rule set, so they take
+// precedence over already added rules. It returns r to allow chaining.
+func (r *Remapper) Prepend(rules ...ErrRemapperFunc) *Remapper {
+	r.rules = append(append(make([]ErrRemapperFunc, 0, len(rules)+len(r.rules)), rules...), r.rules...)
+	return r
+}
+
+// KeepOriginal makes Remap to keep original error inside of remapped one (see
+// WithOriginal), so its stack trace and attached context are not lost. It
+// returns r to allow chaining.
+func (r *Remapper) KeepOriginal() *Remapper {
+	r.keepOriginal = true
+	return r
+}
+
+// Debug makes Remap to record, which rule matched, in remapped error, so it
+// can be retrieved later with RemapTrace. It's intended for diagnosing
+// remapping rules, which swallow context. It returns r to allow chaining.
+func (r *Remapper) Debug() *Remapper {
+	r.debug = true
+	return r
+}
+
+// Remap remaps err with first matched rule. Each rule is checked against
+// every error in the chain, from outermost to innermost, and converter of
+// the rule receives matched error of the chain. If no rule matched, err is
+// returned as is.
+func (r *Remapper) Remap(err error) error {
+	if err == nil {
+		return nil
+	}
+	for i, rule := range r.rules {
+		if e, ok := remapChain(err, rule); ok {
+			out := e
+			if r.keepOriginal {
+				out = WithOriginal(e, err)
+			}
+			if r.debug && out != nil {
+				out = &remapTraced{cause: out, step: RemapStep{
+					Rule:   ruleName(rule),
+					Index:  i,
+					Input:  err,
+					Output: e,
+				}}
+			}
+			return out
+		}
+	}
+	return err
+}
+
+func remapChain(err error, rule ErrRemapperFunc) (res error, ok bool) {
+	walkTree(err, func(e error) {
+		if !ok {
+			res, ok = rule(e)
+		}
+	})
+	return res, ok
+}
+
+// remapped is an error, which looks like the error it was remapped to, but
+// keeps original error as its cause.
+type remapped struct {
+	to   error
+	from error
+}
+
+// WithOriginal returns an error, which has message of to and matches to in
+// Is and As, but Unwrap of which returns from. So remapped error keeps stack
+// trace, fields and all the other context, attached to the original one.
+// If to is nil, WithOriginal returns nil. If from is nil, it returns to.
+func WithOriginal(to, from error) error {
+	if to == nil || from == nil || to == from {
+		return to
+	}
+	return &remapped{to: to, from: from}
+}
+
+func (r *remapped) Error() string   { return r.to.Error() }
+func (r *remapped) Unwrap() error   { return r.from }
+func (r *remapped) message() string { return r.to.Error() }
+
+func (r *remapped) Is(target error) bool         { return Is(r.to, target) }
+func (r *remapped) As(target interface{}) bool   { return As(r.to, target) }
+func (r *remapped) MarshalJSON() ([]byte, error) { return ToJSON(r) }
+
+func (r *remapped) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, r.to.Error()+"\nremapped from: ")
+			formatDetailed(s, r.from)
+			formatFooter(s, r.from)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
+
 func ValueRemapper(comparedErr, convertTo error) ErrRemapperFunc {
 	return ValueRemapperFunc(comparedErr, ConstConverter(convertTo))
 }
@@ -47,6 +178,73 @@ func ValueRemapperFunc(comparedErr error, converter ErrConverter) ErrRemapperFun
 	}
 }
 
+// IsRemapper matches errors, which are equal to target in terms of Is, so
+// wrapped errors are matched too.
+func IsRemapper(target, convertTo error) ErrRemapperFunc {
+	return IsRemapperFunc(target, ConstConverter(convertTo))
+}
+
+// IsRemapperFunc matches errors, which are equal to target in terms of Is,
+// and converts them with converter.
+func IsRemapperFunc(target error, converter ErrConverter) ErrRemapperFunc {
+	return PredicateRemapper(func(err error) bool { return Is(err, target) }, converter)
+}
+
+// PredicateRemapper matches errors, for which match returns true, and
+// converts them with converter.
+func PredicateRemapper(match func(error) bool, converter ErrConverter) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		if match(err) {
+			return converter(err), true
+		}
+		return nil, false
+	}
+}
+
+// MessageRemapper matches errors, which message contains substr. It's useful
+// for third-party libraries, which don't expose any error values or types.
+func MessageRemapper(substr string, convertTo error) ErrRemapperFunc {
+	return MessageRemapperFunc(substr, ConstConverter(convertTo))
+}
+
+// MessageRemapperFunc matches errors, which message contains substr, and
+// converts them with converter.
+func MessageRemapperFunc(substr string, converter ErrConverter) ErrRemapperFunc {
+	return PredicateRemapper(func(err error) bool { return strings.Contains(err.Error(), substr) }, converter)
+}
+
+// RegexpRemapper matches errors, which message matches re.
+func RegexpRemapper(re *regexp.Regexp, convertTo error) ErrRemapperFunc {
+	return RegexpRemapperFunc(re, ConstConverter(convertTo))
+}
+
+// RegexpRemapperFunc matches errors, which message matches re, and converts
+// them with converter.
+func RegexpRemapperFunc(re *regexp.Regexp, converter ErrConverter) ErrRemapperFunc {
+	return PredicateRemapper(func(err error) bool { return re.MatchString(err.Error()) }, converter)
+}
+
+// RegexpTemplateRemapper matches errors, which message matches re, and
+// replaces them with an error with message built from template. Template
+// may refer to capture groups of re in the same way, as regexp.Expand does:
+//
+//	RegexpTemplateRemapper(
+//		regexp.MustCompile(`duplicate key value violates unique constraint "(?P<name>\w+)"`),
+//		"already exists: ${name}",
+//	)
+//
+// Original error is kept as a cause of new one (see WithOriginal).
+func RegexpTemplateRemapper(re *regexp.Regexp, template string) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		msg := err.Error()
+		match := re.FindStringSubmatchIndex(msg)
+		if match == nil {
+			return nil, false
+		}
+		return WithOriginal(NoStack(string(re.ExpandString(nil, template, msg, match))), err), true
+	}
+}
+
 func TypeRemapperLegacy(T, convertTo error) ErrRemapperFunc {
 	return TypeRemapperLegacyF(T, ConstConverter(convertTo))
 }
@@ -71,3 +269,42 @@ func ErrConstantWrap(message string, args ...interface{}) ErrRemapperFunc {
 		return wrap(err, fmt.Sprintf(message, args...), 1), true
 	}
 }
+
+// KindRemapper matches errors, which kind (see KindOf) is equal to kind.
+func KindRemapper(kind Kind, convertTo error) ErrRemapperFunc {
+	return KindRemapperFunc(kind, ConstConverter(convertTo))
+}
+
+// KindRemapperFunc matches errors, which kind (see KindOf) is equal to kind,
+// and converts them with converter.
+func KindRemapperFunc(kind Kind, converter ErrConverter) ErrRemapperFunc {
+	return PredicateRemapper(func(err error) bool { return KindOf(err) == kind }, converter)
+}
+
+// SeverityRemapper matches errors, which severity (see Severity) is equal to
+// level, e.g. to keep soft failures out of error responses.
+func SeverityRemapper(level Level, convertTo error) ErrRemapperFunc {
+	return SeverityRemapperFunc(level, ConstConverter(convertTo))
+}
+
+// SeverityRemapperFunc matches errors, which severity (see Severity) is equal
+// to level, and converts them with converter.
+func SeverityRemapperFunc(level Level, converter ErrConverter) ErrRemapperFunc {
+	return PredicateRemapper(func(err error) bool { return Severity(err) == level }, converter)
+}
+
+// CodeRemapper matches errors, which error code (see Code) is equal to code.
+func CodeRemapper(code int, convertTo error) ErrRemapperFunc {
+	return CodeRemapperFunc(code, ConstConverter(convertTo))
+}
+
+// CodeRemapperFunc matches errors, which error code (see Code) is equal to
+// code, and converts them with converter.
+func CodeRemapperFunc(code int, converter ErrConverter) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		if IsCode(err, code) {
+			return converter(err), true
+		}
+		return nil, false
+	}
+}
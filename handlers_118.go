@@ -14,3 +14,23 @@ func TypeRemapperFunc[T error](converter ErrConverter) ErrRemapperFunc {
 		return nil, false
 	}
 }
+
+// RemapperFor matches errors of type T anywhere in the chain (in terms of As)
+// and converts matched value with typed converter, so custom remappers don't
+// need type assertions:
+//
+//	errors.RemapperFor(func(e *pq.Error) error {
+//		if e.Code == "23505" {
+//			return ErrAlreadyExists
+//		}
+//		return e
+//	})
+func RemapperFor[T error](convert func(T) error) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		var target T
+		if !As(err, &target) {
+			return nil, false
+		}
+		return convert(target), true
+	}
+}
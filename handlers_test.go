@@ -0,0 +1,191 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type remapTestErr struct{ msg string }
+
+func (e *remapTestErr) Error() string { return e.msg }
+
+func TestRemapper(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errTimeout := errors.New("timeout")
+	errType := errors.New("type")
+
+	r := errors.NewRemapper(
+		errors.ValueRemapper(io.EOF, errNotFound),
+		errors.TypeRemapper[*remapTestErr](errType),
+	).Add(
+		errors.ValueRemapper(io.ErrUnexpectedEOF, errTimeout),
+	)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"top", io.EOF, errNotFound},
+		{"wrapped", errors.Wrap(io.EOF, "read"), errNotFound},
+		{"deep", errors.Wrap(errors.WithCode(errors.Wrap(io.ErrUnexpectedEOF, "a"), 1), "b"), errTimeout},
+		{"joined", errors.Join(io.ErrClosedPipe, errors.Wrap(io.EOF, "read")), errNotFound},
+		{"type", errors.Wrap(&remapTestErr{"x"}, "wrap"), errType},
+		{"first rule wins", errors.Join(io.ErrUnexpectedEOF, io.EOF), errNotFound},
+		{"no match", io.ErrClosedPipe, io.ErrClosedPipe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, r.Remap(tt.err))
+		})
+	}
+}
+
+func TestRemapperPrepend(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	var r errors.Remapper
+	r.Add(errors.ValueRemapper(io.EOF, errA))
+	require.Equal(t, errA, r.Remap(io.EOF))
+
+	r.Prepend(errors.ValueRemapper(io.EOF, errB))
+	require.Equal(t, errB, r.Remap(io.EOF))
+}
+
+func TestRemapperConverterReceivesMatched(t *testing.T) {
+	var got error
+	r := errors.NewRemapper(errors.TypeRemapperFunc[*remapTestErr](func(err error) error {
+		got = err
+		return err
+	}))
+
+	orig := &remapTestErr{"x"}
+	require.Equal(t, orig, r.Remap(errors.Wrap(orig, "wrap")))
+	require.Equal(t, orig, got)
+}
+
+func TestIsRemapper(t *testing.T) {
+	errNotFound := errors.New("not found")
+	remappers := []errors.ErrRemapperFunc{
+		errors.IsRemapper(io.EOF, errNotFound),
+	}
+
+	require.Equal(t, errNotFound, errors.Remap(io.EOF, remappers))
+	require.Equal(t, errNotFound, errors.Remap(errors.Wrap(io.EOF, "read"), remappers))
+	require.Equal(t, io.ErrUnexpectedEOF, errors.Remap(io.ErrUnexpectedEOF, remappers))
+}
+
+func TestPredicateRemapper(t *testing.T) {
+	remappers := []errors.ErrRemapperFunc{
+		errors.PredicateRemapper(func(err error) bool {
+			code, ok := errors.Code(err)
+			return ok && code >= 500
+		}, func(err error) error {
+			return errors.Wrap(err, "server error")
+		}),
+	}
+
+	err := errors.WithCode(io.EOF, 503)
+	got := errors.Remap(err, remappers)
+	require.EqualError(t, got, "server error: EOF")
+	require.True(t, errors.Is(got, io.EOF))
+
+	err = errors.WithCode(io.EOF, 404)
+	require.Equal(t, err, errors.Remap(err, remappers))
+}
+
+func TestWithOriginal(t *testing.T) {
+	errNotFound := errors.NoStack("not found")
+	orig := errors.WithField(errors.Wrap(io.EOF, "read"), "id", 42)
+
+	err := errors.WithOriginal(errNotFound, orig)
+	require.EqualError(t, err, "not found")
+	require.True(t, errors.Is(err, errNotFound))
+	require.True(t, errors.Is(err, io.EOF))
+	require.Equal(t, orig, errors.Unwrap(err))
+	require.Equal(t, errors.Stack(orig), errors.Stack(err))
+	require.Equal(t, map[string]interface{}{"id": 42}, errors.Fields(err))
+	require.Regexp(t, `^not found\nremapped from: read: EOF\n`, fmt.Sprintf("%+v", err))
+
+	require.Nil(t, errors.WithOriginal(nil, orig))
+	require.Equal(t, errNotFound, errors.WithOriginal(errNotFound, nil))
+}
+
+func TestRemapperKeepOriginal(t *testing.T) {
+	errNotFound := errors.New("not found")
+	orig := errors.Wrap(io.EOF, "read")
+
+	err := errors.NewRemapper(errors.IsRemapper(io.EOF, errNotFound)).KeepOriginal().Remap(orig)
+	require.EqualError(t, err, "not found")
+	require.True(t, errors.Is(err, errNotFound))
+	require.Equal(t, orig, errors.Unwrap(err))
+	require.Equal(t, errors.Stack(orig), errors.Stack(err))
+}
+
+func TestMessageRemapper(t *testing.T) {
+	errExists := errors.New("already exists")
+	errConn := errors.New("connection error")
+	remappers := []errors.ErrRemapperFunc{
+		errors.MessageRemapper("duplicate key", errExists),
+		errors.RegexpRemapper(regexp.MustCompile(`^dial tcp .+: connection refused$`), errConn),
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"substring", errors.NoStack(`pq: duplicate key value violates unique constraint "users_pkey"`), errExists},
+		{"regexp", errors.NoStack("dial tcp 127.0.0.1:5432: connection refused"), errConn},
+		{"no match", io.EOF, io.EOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Remap(tt.err, remappers))
+		})
+	}
+}
+
+func TestRegexpTemplateRemapper(t *testing.T) {
+	remappers := []errors.ErrRemapperFunc{
+		errors.RegexpTemplateRemapper(
+			regexp.MustCompile(`unique constraint "(?P<name>\w+)"`),
+			"already exists: ${name}",
+		),
+	}
+
+	orig := errors.New(`pq: duplicate key value violates unique constraint "users_pkey"`)
+	err := errors.Remap(orig, remappers)
+	require.EqualError(t, err, "already exists: users_pkey")
+	require.Equal(t, orig, errors.Unwrap(err))
+	require.Equal(t, errors.Stack(orig), errors.Stack(err))
+
+	require.Equal(t, io.EOF, errors.Remap(io.EOF, remappers))
+}
+
+func TestRemapperFor(t *testing.T) {
+	errNotFound := errors.New("not found")
+	remappers := []errors.ErrRemapperFunc{
+		errors.RemapperFor(func(e *remapTestErr) error {
+			if e.msg == "missing" {
+				return errNotFound
+			}
+			return e
+		}),
+	}
+
+	require.Equal(t, errNotFound, errors.Remap(errors.Wrap(&remapTestErr{"missing"}, "get"), remappers))
+	orig := &remapTestErr{"other"}
+	require.Equal(t, orig, errors.Remap(errors.Wrap(orig, "get"), remappers))
+	require.Equal(t, io.EOF, errors.Remap(io.EOF, remappers))
+}
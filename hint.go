@@ -0,0 +1,42 @@
+package errors
+
+import "fmt"
+
+type withHint struct {
+	cause error
+	hint  string
+}
+
+// WithHint annotates err with a hint for humans: what can be done to fix the
+// failure, e.g. "check that DATABASE_URL is set". Hints don't change message
+// of the error, but they are rendered by Explain next to the message of the
+// error they annotate, and can be extracted with Hints. Hints are kept by
+// ToJSON, Encode and protobuf representation, so they cross service
+// boundaries (see also SanitizePolicy.KeepHints).
+// If err is nil, WithHint returns nil.
+func WithHint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &withHint{cause: err, hint: hint}
+}
+
+func (w *withHint) Error() string   { return w.cause.Error() }
+func (w *withHint) Unwrap() error   { return w.cause }
+func (w *withHint) message() string { return "" }
+
+func (w *withHint) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withHint) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Hints returns all hints attached to errors in err's tree, from the
+// outermost to the innermost one.
+func Hints(err error) []string {
+	var res []string
+	walkTree(err, func(e error) {
+		if h, ok := e.(*withHint); ok {
+			res = append(res, h.hint)
+		}
+	})
+	return res
+}
@@ -0,0 +1,23 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestWithHint(t *testing.T) {
+	require.NoError(t, errors.WithHint(nil, "retry"))
+
+	err := errors.WithHint(errors.Wrap(errors.WithHint(io.EOF, "check network"), "read"), "retry later")
+	require.EqualError(t, err, "read: EOF")
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []string{"retry later", "check network"}, errors.Hints(err))
+	require.Regexp(t, `^read: EOF\n.+\.TestWithHint\n`, fmt.Sprintf("%+v", err))
+
+	require.Nil(t, errors.Hints(io.EOF))
+}
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type withHTTPStatus struct {
+	cause  error
+	status int
+}
+
+// WithHTTPStatus annotates err with HTTP status code, which can be extracted
+// later with HTTPStatus.
+// If err is nil, WithHTTPStatus returns nil.
+func WithHTTPStatus(err error, status int) error {
+	if err == nil {
+		return nil
+	}
+	return &withHTTPStatus{cause: err, status: status}
+}
+
+func (w *withHTTPStatus) Error() string   { return w.cause.Error() }
+func (w *withHTTPStatus) Unwrap() error   { return w.cause }
+func (w *withHTTPStatus) HTTPStatus() int { return w.status }
+func (w *withHTTPStatus) message() string { return "" }
+
+func (w *withHTTPStatus) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withHTTPStatus) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// HTTPStatus returns HTTP status code of the outermost error in err's chain,
+// which implements HTTPStatus() int method (e.g. created by WithHTTPStatus).
+// If there is no such error, HTTPStatus returns 500 Internal Server Error.
+// If err is nil, HTTPStatus returns 200 OK.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	for ; err != nil; err = Unwrap(err) {
+		if s, ok := err.(interface{ HTTPStatus() int }); ok {
+			return s.HTTPStatus()
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Problem is a problem details object, defined by RFC 7807.
+type Problem struct {
+	Type     string   `json:"type,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Status   int      `json:"status,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Instance string   `json:"instance,omitempty"`
+	Stack    []string `json:"stack,omitempty"`
+}
+
+// NewProblem builds problem details of err. Detail is the public message of
+// err (see Public), so internal details don't leak to clients. Full message
+// and stack trace are included only in debug mode.
+func NewProblem(err error, debug bool) Problem {
+	status := HTTPStatus(err)
+	p := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	p.Detail = Public(err)
+	if debug {
+		if err != nil {
+			p.Detail = err.Error()
+		}
+		for _, f := range Stack(err) {
+			text, _ := f.MarshalText()
+			p.Stack = append(p.Stack, string(text))
+		}
+	}
+	return p
+}
+
+// WriteError writes problem details of err (see NewProblem) into w as
+// application/problem+json body with err's HTTP status code. Only public
+// message of err is sent (see Public), set it with WithSafeMessage or
+// Sanitize. If err is nil, WriteError writes nothing.
+func WriteError(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeProblem(w, NewProblem(err, false))
+	}
+}
+
+// WriteErrorDebug works like WriteError, but sends full message and stack
+// trace of err. It must not be used in production.
+func WriteErrorDebug(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeProblem(w, NewProblem(err, true))
+	}
+}
+
+// writeProblem encodes p before writing headers, so if encoding fails, plain
+// text response with the same status is written instead. Errors of writing
+// the body are ignored: the status is already sent, and client can't be
+// notified anyway.
+func writeProblem(w http.ResponseWriter, p Problem) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(p.Status), p.Status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(append(body, '\n'))
+}
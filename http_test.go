@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type teapotErr struct{}
+
+func (teapotErr) Error() string   { return "teapot" }
+func (teapotErr) HTTPStatus() int { return http.StatusTeapot }
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"no status", io.EOF, http.StatusInternalServerError},
+		{"status", errors.Wrap(errors.WithHTTPStatus(io.EOF, http.StatusNotFound), "get"), http.StatusNotFound},
+		{"outer wins", errors.WithHTTPStatus(errors.WithHTTPStatus(io.EOF, 404), 409), http.StatusConflict},
+		{"foreign", errors.Wrap(teapotErr{}, "brew"), http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.HTTPStatus(tt.err))
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	err := errors.WithHTTPStatus(errors.New("select from users: no rows"), http.StatusNotFound)
+
+	rec := httptest.NewRecorder()
+	errors.WriteError(rec, err)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "internal error"
+	}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	errors.WriteError(rec, errors.WithSafeMessage(err, "user not found"))
+	require.Contains(t, rec.Body.String(), `"detail":"user not found"`)
+
+	rec = httptest.NewRecorder()
+	errors.WriteErrorDebug(rec, err)
+	var p errors.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.Equal(t, "select from users: no rows", p.Detail)
+	require.NotEmpty(t, p.Stack)
+	require.Regexp(t, `^`+errors.PkgName+`\.TestWriteError `, p.Stack[0])
+}
+
+func TestWriteErrorNil(t *testing.T) {
+	rec := httptest.NewRecorder()
+	errors.WriteError(rec, nil)
+	require.False(t, rec.Flushed)
+	require.Empty(t, rec.Header().Get("Content-Type"))
+	require.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	errors.WriteErrorDebug(rec, nil)
+	require.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// MessageCatalog provides translations of user-facing error messages.
+type MessageCatalog interface {
+	// Lookup returns message template of key in lang language.
+	Lookup(lang, key string) (string, bool)
+}
+
+// MapCatalog is a MessageCatalog, based on map of languages to maps of keys
+// to message templates.
+type MapCatalog map[string]map[string]string
+
+// Lookup implements MessageCatalog.
+func (c MapCatalog) Lookup(lang, key string) (string, bool) {
+	msg, ok := c[lang][key]
+	return msg, ok
+}
+
+type withMessageKey struct {
+	cause error
+	key   string
+	args  []Field
+}
+
+// WithMessageKey annotates err with a key of user-facing message and its
+// arguments as alternating keys and values. Message is rendered later with
+// Localize, while message of err, stack trace and the rest of chain stay
+// untouched. Arguments are also available in Fields.
+// If err is nil, WithMessageKey returns nil.
+func WithMessageKey(err error, key string, keyvals ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withMessageKey{cause: err, key: key, args: kvToFields(keyvals)}
+}
+
+func (w *withMessageKey) Error() string   { return w.cause.Error() }
+func (w *withMessageKey) Unwrap() error   { return w.cause }
+func (w *withMessageKey) fields() []Field { return w.args }
+func (w *withMessageKey) message() string { return "" }
+
+func (w *withMessageKey) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withMessageKey) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// MessageKey returns the outermost message key in err's chain. If there is
+// no key, MessageKey returns false.
+func MessageKey(err error) (string, bool) {
+	for ; err != nil; err = Unwrap(err) {
+		if w, ok := err.(*withMessageKey); ok {
+			return w.key, true
+		}
+	}
+	return "", false
+}
+
+var messagePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// Localize renders user-facing message of err in lang language, using the
+// outermost message key in err's chain (see WithMessageKey). Template can
+// refer to arguments as {name}: arguments of message key take precedence
+// over other fields of err (see Fields and NewT).
+// If err has no message key or catalog has no translation of it, Localize
+// returns false.
+func Localize(err error, lang string, catalog MessageCatalog) (string, bool) {
+	var w *withMessageKey
+	for e := err; e != nil && w == nil; e = Unwrap(e) {
+		w, _ = e.(*withMessageKey)
+	}
+	if w == nil {
+		return "", false
+	}
+	tmpl, ok := catalog.Lookup(lang, w.key)
+	if !ok {
+		return "", false
+	}
+
+	fields := Fields(err)
+	for _, arg := range w.args {
+		fields[arg.Key] = arg.Value
+	}
+	return messagePlaceholder.ReplaceAllStringFunc(tmpl, func(s string) string {
+		if v, ok := fields[s[1:len(s)-1]]; ok {
+			return fmt.Sprint(v)
+		}
+		return s
+	}), true
+}
@@ -0,0 +1,50 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestLocalize(t *testing.T) {
+	catalog := errors.MapCatalog{
+		"en": {"user.not_found": "User {user} not found in {shard}"},
+		"ru": {"user.not_found": "Пользователь {user} не найден"},
+	}
+
+	base := errors.NewT("user %v not found", errors.Arg("user", "alice"))
+	err := errors.Wrap(errors.WithMessageKey(base, "user.not_found", "shard", 3), "get user")
+
+	tests := []struct {
+		name   string
+		err    error
+		lang   string
+		want   string
+		wantOk bool
+	}{
+		{"en", err, "en", "User alice not found in 3", true},
+		{"ru", err, "ru", "Пользователь alice не найден", true},
+		{"no translation", err, "de", "", false},
+		{"no key", io.EOF, "en", "", false},
+		{"nil", nil, "en", "", false},
+		{"missing arg", errors.WithMessageKey(io.EOF, "user.not_found", "user", "bob"), "en", "User bob not found in {shard}", true},
+		{"key args win", errors.WithMessageKey(base, "user.not_found", "user", "bob", "shard", 1), "en", "User bob not found in 1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := errors.Localize(tt.err, tt.lang, catalog)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantOk, ok)
+		})
+	}
+
+	require.EqualError(t, err, "get user: user alice not found")
+	require.Equal(t, errors.Stack(base), errors.Stack(err))
+	key, ok := errors.MessageKey(err)
+	require.True(t, ok)
+	require.Equal(t, "user.not_found", key)
+}
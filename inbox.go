@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+// Envelope is an error received by Inbox with its origin.
+type Envelope struct {
+	Err error
+	// Goroutine is identifier of goroutine, which sent the error.
+	Goroutine uint64
+}
+
+// Inbox is a bounded queue of errors, which lets background goroutines
+// surface their errors into the main control loop:
+//
+//	inbox := errors.NewInbox(16)
+//	go func() { inbox.Send(work()) }()
+//	...
+//	select {
+//	case e := <-inbox.C():
+//		log.Printf("worker %d failed: %+v", e.Goroutine, e.Err)
+//	case <-ctx.Done():
+//	}
+//
+// Send never blocks: if buffer is full, error is dropped and counted (see
+// Dropped). Inbox is safe for concurrent use.
+type Inbox struct {
+	// dropped is accessed atomically, so it goes first to be 64-bit aligned
+	// on 32-bit platforms.
+	dropped uint64
+	ch      chan Envelope
+}
+
+// NewInbox returns Inbox, which buffers up to size errors. If size is not
+// positive, Inbox buffers single error.
+func NewInbox(size int) *Inbox {
+	if size < 1 {
+		size = 1
+	}
+	return &Inbox{ch: make(chan Envelope, size)}
+}
+
+// Send puts err into the inbox, recording identifier of current goroutine.
+// If err has no stack trace, stack trace of Send caller is attached to it.
+// Send reports whether err was queued: false means that buffer is full and
+// err was dropped. Nil errors are ignored and reported as queued.
+func (i *Inbox) Send(err error) bool {
+	if err == nil {
+		return true
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	select {
+	case i.ch <- Envelope{Err: err, Goroutine: goroutineID()}:
+		return true
+	default:
+		atomic.AddUint64(&i.dropped, 1)
+		return false
+	}
+}
+
+// C returns channel, which receives queued errors.
+func (i *Inbox) C() <-chan Envelope { return i.ch }
+
+// Drain returns all currently queued errors without blocking.
+func (i *Inbox) Drain() []Envelope {
+	var res []Envelope
+	for {
+		select {
+		case e := <-i.ch:
+			res = append(res, e)
+		default:
+			return res
+		}
+	}
+}
+
+// Err drains the inbox and returns queued errors joined (see Join), or nil,
+// if there are none.
+func (i *Inbox) Err() error {
+	envelopes := i.Drain()
+	errs := make([]error, len(envelopes))
+	for j, e := range envelopes {
+		errs[j] = e.Err
+	}
+	return Join(errs...)
+}
+
+// Dropped returns number of errors, which were dropped, because buffer was
+// full.
+func (i *Inbox) Dropped() uint64 { return atomic.LoadUint64(&i.dropped) }
+
+// goroutineID returns identifier of current goroutine, parsed from header
+// of its traceback ("goroutine 42 [running]:"), or 0, if it can't be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
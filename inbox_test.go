@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestInbox(t *testing.T) {
+	inbox := errors.NewInbox(2)
+	require.True(t, inbox.Send(nil))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		require.True(t, inbox.Send(io.EOF))
+	}()
+	wg.Wait()
+
+	e := <-inbox.C()
+	require.ErrorIs(t, e.Err, io.EOF)
+	require.NotZero(t, e.Goroutine)
+	require.Equal(t, errors.PkgName+".TestInbox.func1", funcName(errors.Stack(e.Err)[0]))
+
+	own := errors.New("own stack")
+	require.True(t, inbox.Send(own))
+	require.True(t, inbox.Send(io.EOF))
+	require.False(t, inbox.Send(io.ErrUnexpectedEOF))
+	require.EqualValues(t, 1, inbox.Dropped())
+
+	got := inbox.Drain()
+	require.Len(t, got, 2)
+	require.Same(t, own, got[0].Err)
+	require.NotEqual(t, e.Goroutine, got[0].Goroutine)
+	require.Empty(t, inbox.Drain())
+}
+
+func TestInboxErr(t *testing.T) {
+	inbox := errors.NewInbox(0)
+	require.NoError(t, inbox.Err())
+
+	inbox.Send(io.EOF)
+	err := inbox.Err()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, inbox.Err())
+}
@@ -0,0 +1,15 @@
+//go:build go1.23
+
+package errors
+
+import "iter"
+
+// Chain returns iterator over every error in err's tree in the same order as
+// Walk does:
+//
+//	for e := range errors.Chain(err) {
+//		...
+//	}
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) { walk(err, yield) }
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestChain(t *testing.T) {
+	first := errors.New("first")
+	err := errors.Wrap(errors.Join(first, io.EOF), "joined")
+
+	var want []error
+	errors.Walk(err, func(e error) bool {
+		want = append(want, e)
+		return true
+	})
+
+	var got []error
+	for e := range errors.Chain(err) {
+		got = append(got, e)
+	}
+	require.Equal(t, want, got)
+
+	got = got[:0]
+	for e := range errors.Chain(err) {
+		if e == first {
+			break
+		}
+		got = append(got, e)
+	}
+	require.Equal(t, want[:indexOf(want, first)], got)
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// jsonError is a serializable representation of single error in the chain.
+type jsonError struct {
+	Message string                 `json:"message"`
+	Stack   StackTrace             `json:"stack,omitempty"`
+	At      Frame                  `json:"at,omitempty"`
+	Code    *int                   `json:"code,omitempty"`
+	Kind    string                 `json:"kind,omitempty"`
+	Level   string                 `json:"severity,omitempty"`
+	Build   *BuildInfo             `json:"build,omitempty"`
+	Hint    string                 `json:"hint,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Cause   *jsonError             `json:"cause,omitempty"`
+	Causes  []*jsonError           `json:"causes,omitempty"`
+	// Context keeps fields of enrichers (see AddEnricher), it's set only for
+	// the outermost object.
+	Context map[string]interface{} `json:"context,omitempty"`
+}
+
+// ToJSON returns JSON representation of err's chain in the form of
+//
+//	{"message": "...", "stack": ["pkg.Func /path/file.go:42", ...], "fields": {...}, "cause": {...}}
+//
+// Each error in the chain becomes its own object, errors aggregated by
+// multi-errors are listed in "causes" array. If err is nil, ToJSON returns
+// JSON null.
+//
+// ToJSON contains only data of err itself, so it's suitable to send errors
+// to other services. Use ToJSONEnriched for reporting output.
+func ToJSON(err error) ([]byte, error) { return json.Marshal(toJSONError(err)) }
+
+// ToJSONEnriched works like ToJSON, but also adds fields of registered
+// enrichers (see AddEnricher) to the outermost object as "context". It's
+// intended for reporting output (logs, error trackers), since enrichers
+// describe the host and the process, which must not leak to other services.
+func ToJSONEnriched(err error) ([]byte, error) {
+	res := toJSONError(err)
+	if res != nil {
+		for _, field := range Enrichment() {
+			if res.Context == nil {
+				res.Context = make(map[string]interface{})
+			}
+			res.Context[field.Key] = field.Value
+		}
+	}
+	return json.Marshal(res)
+}
+
+func toJSONError(err error) *jsonError {
+	if err == nil {
+		return nil
+	}
+	err = skipAdopted(err)
+	res := &jsonError{Message: err.Error()}
+	if stack, ok := stackOf(err); ok {
+		res.Stack = stack
+	}
+	if w, ok := err.(interface{ wrappedAt() Frame }); ok {
+		res.At = w.wrappedAt()
+	}
+	if c, ok := err.(*withCode); ok {
+		res.Code = &c.code
+	}
+	if k, ok := err.(*withKind); ok {
+		res.Kind = k.kind.String()
+	}
+	if s, ok := err.(*withSeverity); ok {
+		res.Level = s.level.String()
+	}
+	if b, ok := err.(*withBuildInfo); ok {
+		res.Build = &b.info
+	}
+	if h, ok := err.(*withHint); ok {
+		res.Hint = h.hint
+	}
+	if f, ok := err.(interface{ fields() []Field }); ok {
+		for _, field := range f.fields() {
+			if res.Fields == nil {
+				res.Fields = make(map[string]interface{})
+			}
+			res.Fields[field.Key] = field.Value
+		}
+	}
+	switch e := err.(type) {
+	case *truncated:
+		// causes repeat messages, which were truncated
+	case interface{ Unwrap() error }:
+		res.Cause = toJSONError(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			res.Causes = append(res.Causes, toJSONError(child))
+		}
+	}
+	return res
+}
+
+func (f *fundamental) MarshalJSON() ([]byte, error) { return ToJSON(f) }
+func (w *withStack) MarshalJSON() ([]byte, error)   { return ToJSON(w) }
+func (a *adopted) MarshalJSON() ([]byte, error)     { return ToJSON(a) }
+func (w *withMessage) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+func (w *withField) MarshalJSON() ([]byte, error)   { return ToJSON(w) }
+func (w *withParams) MarshalJSON() ([]byte, error)  { return ToJSON(w) }
+func (j *joined) MarshalJSON() ([]byte, error)      { return ToJSON(j) }
+func (o *opaque) MarshalJSON() ([]byte, error)      { return ToJSON(o) }
+func (s *sanitized) MarshalJSON() ([]byte, error)   { return ToJSON(s) }
+
+// FromJSON reconstructs error chain from its JSON representation, produced
+// by ToJSON (possibly by another service). Reconstructed errors keep
+// messages, stack frames and fields of original ones, so Cause, Unwrap,
+// Stack and Fields behave the same way as for the original error. Is and As
+// can't match original error values and types though.
+// If data contains JSON null, FromJSON returns nil error.
+func FromJSON(data []byte) (error, error) {
+	var e *jsonError
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, WithStack(err)
+	}
+	return fromJSONError(e), nil
+}
+
+func fromJSONError(e *jsonError) error {
+	if e == nil {
+		return nil
+	}
+	r := remote{msg: e.Message, stack: e.Stack, at: e.At}
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r.params = append(r.params, Field{Key: k, Value: e.Fields[k]})
+	}
+
+	var res error
+	if len(e.Causes) > 0 {
+		m := &remoteMulti{remote: r}
+		for _, c := range e.Causes {
+			if c != nil {
+				m.causes = append(m.causes, fromJSONError(c))
+			}
+		}
+		res = m
+	} else {
+		r.cause = fromJSONError(e.Cause)
+		res = &r
+	}
+	if e.Code != nil {
+		res = &withCode{cause: res, code: *e.Code}
+	}
+	if kind, ok := parseKind(e.Kind); ok {
+		res = &withKind{cause: res, kind: kind}
+	}
+	if level, ok := parseLevel(e.Level); ok {
+		res = &withSeverity{cause: res, level: level}
+	}
+	if e.Build != nil {
+		res = &withBuildInfo{cause: res, info: *e.Build}
+	}
+	if e.Hint != "" {
+		res = &withHint{cause: res, hint: e.Hint}
+	}
+	return res
+}
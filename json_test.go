@@ -0,0 +1,141 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestToJSON(t *testing.T) {
+	b, err := errors.ToJSON(nil)
+	require.NoError(t, err)
+	require.Equal(t, "null", string(b))
+
+	b, err = json.Marshal(errors.WithField(errors.Wrap(io.EOF, "read"), "user_id", 42))
+	require.NoError(t, err)
+
+	var got struct {
+		Message string
+		Fields  map[string]interface{}
+		Cause   struct {
+			Message string
+			Stack   []string
+			Cause   struct {
+				Message string
+				Cause   struct {
+					Message string
+					Cause   *struct{}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Equal(t, "read: EOF", got.Message)
+	require.Equal(t, map[string]interface{}{"user_id": float64(42)}, got.Fields)
+	require.Equal(t, "read: EOF", got.Cause.Message)
+	require.NotEmpty(t, got.Cause.Stack)
+	require.Regexp(t, `^`+errors.PkgName+`\.TestToJSON .+/json_test\.go:\d+$`, got.Cause.Stack[0])
+	require.Equal(t, "read: EOF", got.Cause.Cause.Message)
+	require.Equal(t, "EOF", got.Cause.Cause.Cause.Message)
+	require.Nil(t, got.Cause.Cause.Cause.Cause)
+}
+
+func TestToJSONJoined(t *testing.T) {
+	b, err := errors.ToJSON(errors.Join(errors.NoStack("first"), io.EOF))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"message": "first\nEOF",
+		"causes": [{"message": "first"}, {"message": "EOF"}]
+	}`, string(b))
+}
+
+func TestFromJSON(t *testing.T) {
+	orig := errors.WithField(errors.Wrap(errors.New("connection reset"), "sync user"), "user_id", 42)
+	b, err := errors.ToJSON(orig)
+	require.NoError(t, err)
+
+	got, err := errors.FromJSON(b)
+	require.NoError(t, err)
+	require.Equal(t, orig.Error(), got.Error())
+	require.Equal(t, "connection reset", errors.Cause(got).Error())
+	require.Equal(t, map[string]interface{}{"user_id": float64(42)}, errors.Fields(got))
+	require.Equal(t, "1. sync user (user_id=42); 2. connection reset", errors.Explain(got))
+	require.Equal(t, fmt.Sprintf("%+v", orig), fmt.Sprintf("%+v", got))
+	require.Equal(t, fmt.Sprintf("%+v", errors.Stack(orig)), fmt.Sprintf("%+v", errors.Stack(got)))
+
+	again, err := errors.ToJSON(got)
+	require.NoError(t, err)
+	require.JSONEq(t, string(b), string(again))
+}
+
+func TestFromJSONHints(t *testing.T) {
+	orig := errors.WithHint(errors.Wrap(errors.WithHint(io.EOF, "check network"), "read"), "retry later")
+	b, err := errors.ToJSON(orig)
+	require.NoError(t, err)
+
+	got, err := errors.FromJSON(b)
+	require.NoError(t, err)
+	require.Equal(t, []string{"retry later", "check network"}, errors.Hints(got))
+	require.Equal(t, errors.Explain(orig), errors.Explain(got))
+}
+
+func TestFromJSONJoined(t *testing.T) {
+	b, err := errors.ToJSON(errors.Join(errors.New("first"), io.EOF))
+	require.NoError(t, err)
+
+	got, err := errors.FromJSON(b)
+	require.NoError(t, err)
+	require.Len(t, errors.Joined(got), 2)
+	require.Len(t, errors.Stacks(got), 1)
+	require.Equal(t, "first\nEOF", got.Error())
+}
+
+func TestFromJSONJoinedAnnotated(t *testing.T) {
+	orig := errors.WithSeverity(errors.WithKind(errors.WithCode(
+		errors.Join(errors.New("first"), io.EOF), 42), errors.KindNotFound), errors.LevelWarning)
+	got, err := errors.FromJSON(mustJSON(t, orig))
+	require.NoError(t, err)
+	require.Len(t, errors.Joined(got), 2)
+	code, ok := errors.Code(got)
+	require.True(t, ok)
+	require.Equal(t, 42, code)
+	require.Equal(t, errors.KindNotFound, errors.KindOf(got))
+	require.Equal(t, errors.LevelWarning, errors.Severity(got))
+
+	// annotations of multi-error node itself, e.g. produced by other
+	// implementations
+	got, err = errors.FromJSON([]byte(`{
+		"message": "first\nEOF",
+		"code": 42,
+		"kind": "not_found",
+		"severity": "warning",
+		"build": {"path": "example.com/app"},
+		"hint": "retry later",
+		"causes": [{"message": "first"}, {"message": "EOF"}]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, errors.Joined(got), 2)
+	code, ok = errors.Code(got)
+	require.True(t, ok)
+	require.Equal(t, 42, code)
+	require.Equal(t, errors.KindNotFound, errors.KindOf(got))
+	require.Equal(t, errors.LevelWarning, errors.Severity(got))
+	info, ok := errors.BuildInfoOf(got)
+	require.True(t, ok)
+	require.Equal(t, "example.com/app", info.Path)
+	require.Equal(t, []string{"retry later"}, errors.Hints(got))
+}
+
+func TestFromJSONNull(t *testing.T) {
+	got, err := errors.FromJSON([]byte("null"))
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	_, err = errors.FromJSON([]byte(`{"message": "x", "stack": ["garbage"]}`))
+	require.Error(t, err)
+}
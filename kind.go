@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Kind is a category of failure, which is common for all services: it gives
+// them single vocabulary of errors, which is mapped into transport layers
+// (see Kind.HTTPStatus and Kind.GRPCCode) instead of dozens of sentinels.
+type Kind uint8
+
+const (
+	// KindUnknown means that error category is unknown.
+	KindUnknown Kind = iota
+	// KindInvalid means that request is invalid.
+	KindInvalid
+	// KindNotFound means that requested entity doesn't exist.
+	KindNotFound
+	// KindConflict means that entity already exists or was changed
+	// concurrently.
+	KindConflict
+	// KindUnauthorized means that caller is not authenticated.
+	KindUnauthorized
+	// KindForbidden means that caller has no permission for the operation.
+	KindForbidden
+	// KindPrecondition means that system is not in a state required for the
+	// operation.
+	KindPrecondition
+	// KindRateLimited means that some resource or quota is exhausted.
+	KindRateLimited
+	// KindCanceled means that operation was canceled by caller.
+	KindCanceled
+	// KindTimeout means that operation deadline was exceeded.
+	KindTimeout
+	// KindUnavailable means that service is temporarily unavailable.
+	KindUnavailable
+	// KindUnimplemented means that operation is not implemented.
+	KindUnimplemented
+	// KindInternal means that some invariant of the system is broken.
+	KindInternal
+)
+
+var kindInfo = [...]struct {
+	name   string
+	status int
+	grpc   uint32
+}{
+	KindUnknown:       {"unknown", http.StatusInternalServerError, 2},
+	KindInvalid:       {"invalid", http.StatusBadRequest, 3},
+	KindNotFound:      {"not_found", http.StatusNotFound, 5},
+	KindConflict:      {"conflict", http.StatusConflict, 6},
+	KindUnauthorized:  {"unauthorized", http.StatusUnauthorized, 16},
+	KindForbidden:     {"forbidden", http.StatusForbidden, 7},
+	KindPrecondition:  {"precondition", http.StatusPreconditionFailed, 9},
+	KindRateLimited:   {"rate_limited", http.StatusTooManyRequests, 8},
+	KindCanceled:      {"canceled", 499, 1},
+	KindTimeout:       {"timeout", http.StatusGatewayTimeout, 4},
+	KindUnavailable:   {"unavailable", http.StatusServiceUnavailable, 14},
+	KindUnimplemented: {"unimplemented", http.StatusNotImplemented, 12},
+	KindInternal:      {"internal", http.StatusInternalServerError, 13},
+}
+
+func (k Kind) String() string {
+	if int(k) < len(kindInfo) {
+		return kindInfo[k].name
+	}
+	return fmt.Sprintf("Kind(%d)", k)
+}
+
+// HTTPStatus returns default HTTP status of errors of kind k. Canceled
+// errors are mapped into non-standard 499 Client Closed Request status.
+func (k Kind) HTTPStatus() int {
+	if int(k) < len(kindInfo) {
+		return kindInfo[k].status
+	}
+	return http.StatusInternalServerError
+}
+
+// GRPCCode returns default canonical gRPC code of errors of kind k.
+func (k Kind) GRPCCode() uint32 {
+	if int(k) < len(kindInfo) {
+		return kindInfo[k].grpc
+	}
+	return kindInfo[KindUnknown].grpc
+}
+
+// parseKind returns kind by its name, returned by String.
+func parseKind(name string) (Kind, bool) {
+	for k, info := range kindInfo {
+		if info.name == name {
+			return Kind(k), true
+		}
+	}
+	return KindUnknown, false
+}
+
+type withKind struct {
+	cause error
+	kind  Kind
+}
+
+// WithKind annotates err with kind of failure, which can be extracted later
+// with KindOf. Annotated error also reports default HTTP status of the kind
+// to HTTPStatus.
+// If err is nil, WithKind returns nil.
+func WithKind(err error, kind Kind) error {
+	if err == nil {
+		return nil
+	}
+	return &withKind{cause: err, kind: kind}
+}
+
+func (w *withKind) Error() string   { return w.cause.Error() }
+func (w *withKind) Unwrap() error   { return w.cause }
+func (w *withKind) HTTPStatus() int { return w.kind.HTTPStatus() }
+func (w *withKind) message() string { return "" }
+func (w *withKind) errorKind() Kind { return w.kind }
+
+func (w *withKind) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withKind) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// KindOf returns the outermost kind in err's chain (see WithKind). If there
+// is no kind, canceled and timed out errors (see IsCanceled and IsTimeout)
+// are classified as KindCanceled and KindTimeout, others are KindUnknown.
+func KindOf(err error) Kind {
+	for e := err; e != nil; e = Unwrap(e) {
+		if k, ok := e.(interface{ errorKind() Kind }); ok {
+			return k.errorKind()
+		}
+	}
+	switch {
+	case IsCanceled(err):
+		return KindCanceled
+	case IsTimeout(err):
+		return KindTimeout
+	default:
+		return KindUnknown
+	}
+}
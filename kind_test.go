@@ -0,0 +1,92 @@
+package errors_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want errors.Kind
+	}{
+		{"nil", nil, errors.KindUnknown},
+		{"plain", io.EOF, errors.KindUnknown},
+		{"kind", errors.WithKind(io.EOF, errors.KindNotFound), errors.KindNotFound},
+		{"wrapped", errors.Wrap(errors.WithKind(io.EOF, errors.KindConflict), "create"), errors.KindConflict},
+		{"outermost", errors.WithKind(errors.WithKind(io.EOF, errors.KindNotFound), errors.KindInternal), errors.KindInternal},
+		{"canceled", errors.Wrap(context.Canceled, "call"), errors.KindCanceled},
+		{"timeout", errors.Wrap(context.DeadlineExceeded, "call"), errors.KindTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.KindOf(tt.err))
+		})
+	}
+}
+
+func TestKindMapping(t *testing.T) {
+	tests := []struct {
+		kind   errors.Kind
+		name   string
+		status int
+		grpc   uint32
+	}{
+		{errors.KindUnknown, "unknown", http.StatusInternalServerError, 2},
+		{errors.KindInvalid, "invalid", http.StatusBadRequest, 3},
+		{errors.KindNotFound, "not_found", http.StatusNotFound, 5},
+		{errors.KindUnauthorized, "unauthorized", http.StatusUnauthorized, 16},
+		{errors.KindCanceled, "canceled", 499, 1},
+		{errors.KindInternal, "internal", http.StatusInternalServerError, 13},
+		{errors.Kind(200), "Kind(200)", http.StatusInternalServerError, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.name, tt.kind.String())
+			require.Equal(t, tt.status, tt.kind.HTTPStatus())
+			require.Equal(t, tt.grpc, tt.kind.GRPCCode())
+		})
+	}
+
+	err := errors.Wrap(errors.WithKind(io.EOF, errors.KindNotFound), "get")
+	require.Equal(t, http.StatusNotFound, errors.HTTPStatus(err))
+	require.Equal(t, http.StatusTeapot, errors.HTTPStatus(errors.WithHTTPStatus(err, http.StatusTeapot)))
+}
+
+func TestKindRemapper(t *testing.T) {
+	errNotFound := errors.New("not found")
+	remappers := []errors.ErrRemapperFunc{
+		errors.KindRemapper(errors.KindNotFound, errNotFound),
+	}
+
+	require.Equal(t, errNotFound, errors.Remap(errors.WithKind(io.EOF, errors.KindNotFound), remappers))
+	require.Equal(t, io.EOF, errors.Remap(io.EOF, remappers))
+}
+
+func TestKindSerialization(t *testing.T) {
+	err := errors.WithKind(errors.WithCode(errors.New("not found"), 42), errors.KindNotFound)
+
+	data, jsonErr := errors.ToJSON(err)
+	require.NoError(t, jsonErr)
+	require.Contains(t, string(data), `"kind":"not_found"`)
+	got, jsonErr := errors.FromJSON(data)
+	require.NoError(t, jsonErr)
+	require.Equal(t, errors.KindNotFound, errors.KindOf(got))
+	require.True(t, errors.IsCode(got, 42))
+
+	data, binErr := errors.Encode(err)
+	require.NoError(t, binErr)
+	got, binErr = errors.Decode(data)
+	require.NoError(t, binErr)
+	require.Equal(t, errors.KindNotFound, errors.KindOf(got))
+	require.True(t, errors.IsCode(got, 42))
+}
@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+)
+
+// GoroutineLabel is a key, under which Labels reports identifier of the
+// goroutine, where error was annotated.
+const GoroutineLabel = "goroutine"
+
+// goroutineLabels is non-zero, if constructors annotate errors with
+// goroutine identifier, see SetGoroutineLabels.
+var goroutineLabels int32
+
+// SetGoroutineLabels enables or disables automatic annotation of errors
+// with identifier of the goroutine, which created them: while enabled, errors
+// created by New, Errorf, Wrap, WithStack and other constructors, which
+// record stack trace, are annotated as with WithLabels, unless they already
+// have labels in their chain. Go runtime doesn't expose pprof labels of
+// current goroutine without its context, so use WithLabels to record them.
+//
+// Parsing goroutine identifier costs about a microsecond per error, so it's
+// disabled by default.
+func SetGoroutineLabels(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&goroutineLabels, v)
+}
+
+type withLabels struct {
+	cause  error
+	labels map[string]string
+}
+
+// WithLabels annotates err with pprof labels of ctx (see pprof.WithLabels)
+// and identifier of current goroutine, which can be extracted later with
+// Labels. It lets correlate errors with per-request labels, which are set by
+// middleware for profiling. ctx may be nil, then only goroutine is recorded.
+// If err is nil, WithLabels returns nil.
+func WithLabels(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	labels := map[string]string{}
+	if ctx != nil {
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			labels[key] = value
+			return true
+		})
+	}
+	return &withLabels{cause: err, labels: withGoroutine(labels)}
+}
+
+// withGoroutine adds identifier of current goroutine to labels, if it's
+// known and not set explicitly.
+func withGoroutine(labels map[string]string) map[string]string {
+	if _, ok := labels[GoroutineLabel]; ok {
+		return labels
+	}
+	if id := goroutineID(); id != 0 {
+		labels[GoroutineLabel] = strconv.FormatUint(id, 10)
+	}
+	return labels
+}
+
+func (w *withLabels) Error() string   { return w.cause.Error() }
+func (w *withLabels) Unwrap() error   { return w.cause }
+func (w *withLabels) message() string { return "" }
+
+func (w *withLabels) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withLabels) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Labels returns labels, err was annotated with (see WithLabels and
+// SetGoroutineLabels), merged along err's chain. If the same label is set
+// several times, the innermost value wins, since it's the closest one to
+// the point, where failure occurred. If there are no labels, Labels returns
+// nil.
+func Labels(err error) map[string]string {
+	var res map[string]string
+	for ; err != nil; err = Unwrap(err) {
+		w, ok := err.(*withLabels)
+		if !ok {
+			continue
+		}
+		if res == nil {
+			res = make(map[string]string, len(w.labels))
+		}
+		for k, v := range w.labels {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// hasLabels reports whether err's chain has labels annotation.
+func hasLabels(err error) bool {
+	for ; err != nil; err = Unwrap(err) {
+		if _, ok := err.(*withLabels); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package errors_test
+
+import (
+	"context"
+	"io"
+	"runtime/pprof"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestWithLabels(t *testing.T) {
+	require.Nil(t, errors.WithLabels(context.Background(), nil))
+	require.Nil(t, errors.Labels(io.EOF))
+
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("route", "/users", "user", "42"))
+	inner := errors.WithLabels(ctx, io.EOF)
+
+	ctx = pprof.WithLabels(context.Background(), pprof.Labels("route", "/outer", "attempt", "2"))
+	err := errors.WithLabels(ctx, errors.Wrap(inner, "sync"))
+
+	labels := errors.Labels(err)
+	require.Equal(t, "/users", labels["route"], "innermost label must win")
+	require.Equal(t, "42", labels["user"])
+	require.Equal(t, "2", labels["attempt"])
+	id, parseErr := strconv.ParseUint(labels[errors.GoroutineLabel], 10, 64)
+	require.NoError(t, parseErr)
+	require.NotZero(t, id)
+
+	require.Equal(t, "sync: EOF", err.Error())
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestSetGoroutineLabels(t *testing.T) {
+	errors.SetGoroutineLabels(true)
+	defer errors.SetGoroutineLabels(false)
+
+	err := errors.New("boom")
+	require.NotEmpty(t, errors.Labels(err)[errors.GoroutineLabel])
+	require.NotNil(t, errors.Stack(err))
+
+	done := make(chan error)
+	go func() { done <- errors.New("boom") }()
+	other := <-done
+	require.NotEqual(t, errors.Labels(err)[errors.GoroutineLabel], errors.Labels(other)[errors.GoroutineLabel])
+
+	errors.SetGoroutineLabels(false)
+	require.Nil(t, errors.Labels(errors.New("boom")))
+}
@@ -0,0 +1,124 @@
+// Package logrushook provides github.com/sirupsen/logrus hook, which
+// extracts stack traces, fields and causes from errors of
+// github.com/quenbyako/errors package.
+//
+//	logrus.AddHook(logrushook.New())
+//	logrus.WithError(err).Error("request failed")
+package logrushook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/quenbyako/errors"
+)
+
+// Keys of entry fields populated by Hook.
+const (
+	StackTraceKey = "stacktrace"
+	FieldsKey     = "error_fields"
+	CauseKey      = "error_cause"
+	SeverityKey   = "error_severity"
+)
+
+// Hook is a logrus.Hook, which checks entry's error field (logrus.ErrorKey),
+// and, if error carries stack trace or fields, populates entry with:
+//
+//   - StackTraceKey: stack trace of error, formatted as with %+v;
+//   - FieldsKey: fields of error (see errors.Fields);
+//   - CauseKey: message of error's root cause, if it differs from error
+//     itself;
+//   - SeverityKey: severity of error, if it's not errors.LevelError.
+//
+// Fields which are already present in entry are not overwritten.
+type Hook struct {
+	// LogLevels are levels, on which hook is fired. If empty, hook is fired
+	// on all levels.
+	LogLevels []logrus.Level
+	// AdjustLevel makes hook to replace level of entry with level of its
+	// error, see Level.
+	AdjustLevel bool
+}
+
+var _ logrus.Hook = (*Hook)(nil)
+
+// New returns Hook, which is fired on all levels.
+func New() *Hook { return &Hook{} }
+
+// Levels implements logrus.Hook.
+func (h *Hook) Levels() []logrus.Level {
+	if len(h.LogLevels) == 0 {
+		return logrus.AllLevels
+	}
+	return h.LogLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	err, ok := entry.Data[logrus.ErrorKey].(error)
+	if !ok || err == nil {
+		return nil
+	}
+
+	level := errors.Severity(err)
+	if h.AdjustLevel {
+		entry.Level = Level(err)
+	}
+	if level != errors.LevelError {
+		setDefault(entry, SeverityKey, level.String())
+	}
+
+	stack, fields := errors.Stack(err), errors.Fields(err)
+	if len(stack) == 0 && len(fields) == 0 {
+		return nil
+	}
+
+	if len(stack) > 0 {
+		setDefault(entry, StackTraceKey, strings.TrimSuffix(fmt.Sprintf("%+v", stack), "\n"))
+	}
+	if len(fields) > 0 {
+		setDefault(entry, FieldsKey, fields)
+	}
+	if cause := rootCause(err); cause != nil && cause != err {
+		setDefault(entry, CauseKey, cause.Error())
+	}
+
+	return nil
+}
+
+// rootCause works like errors.Cause, but stops at truncated error (see
+// errors.Truncate), so message of the cause is not logged in full.
+func rootCause(err error) error {
+	for !errors.IsTruncated(err) {
+		cause := errors.Unwrap(err)
+		if cause == nil {
+			break
+		}
+		err = cause
+	}
+	return err
+}
+
+// Level returns logrus level of err according to its severity (see
+// errors.Severity): WarnLevel for warnings and ErrorLevel for others. Levels
+// above ErrorLevel exit or panic, so critical errors are logged as errors,
+// but SeverityKey lets alerting distinguish them. If err is nil, Level
+// returns InfoLevel.
+func Level(err error) logrus.Level {
+	switch {
+	case err == nil:
+		return logrus.InfoLevel
+	case errors.Severity(err) == errors.LevelWarning:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
+
+func setDefault(entry *logrus.Entry, key string, value interface{}) {
+	if _, ok := entry.Data[key]; !ok {
+		entry.Data[key] = value
+	}
+}
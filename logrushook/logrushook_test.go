@@ -0,0 +1,90 @@
+package logrushook_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/logrushook"
+)
+
+func newLogger() (*logrus.Logger, *test.Hook) {
+	logger, hook := test.NewNullLogger()
+	logger.AddHook(logrushook.New())
+	return logger, hook
+}
+
+func TestHook(t *testing.T) {
+	logger, hook := newLogger()
+
+	err := errors.Wrap(errors.WithField(io.EOF, "user_id", 7), "read user")
+	logger.WithError(err).Error("failed")
+
+	data := hook.LastEntry().Data
+	require.Equal(t, map[string]interface{}{"user_id": 7}, data[logrushook.FieldsKey])
+	require.Equal(t, "EOF", data[logrushook.CauseKey])
+	require.Regexp(t, `^github.com/quenbyako/errors/logrushook_test.TestHook\n\t.+logrushook_test\.go:\d+`, data[logrushook.StackTraceKey])
+}
+
+func TestHookTruncated(t *testing.T) {
+	logger, hook := newLogger()
+
+	payload := strings.Repeat("x", 100)
+	err := errors.Wrap(errors.Truncate(errors.New("query "+payload), errors.Limits{Message: 10}), "get user")
+	logger.WithError(err).Error("failed")
+
+	data := hook.LastEntry().Data
+	require.Equal(t, "query xxxx... (truncated, 106 bytes total)", data[logrushook.CauseKey])
+}
+
+func TestHookForeignError(t *testing.T) {
+	logger, hook := newLogger()
+
+	logger.WithError(io.EOF).Error("failed")
+
+	data := hook.LastEntry().Data
+	require.NotContains(t, data, logrushook.StackTraceKey)
+	require.NotContains(t, data, logrushook.FieldsKey)
+	require.NotContains(t, data, logrushook.CauseKey)
+}
+
+func TestHookKeepsExistingFields(t *testing.T) {
+	logger, hook := newLogger()
+
+	logger.WithError(errors.New("boom")).WithField(logrushook.StackTraceKey, "custom").Error("failed")
+
+	data := hook.LastEntry().Data
+	require.Equal(t, "custom", data[logrushook.StackTraceKey])
+	require.NotContains(t, data, logrushook.CauseKey)
+}
+
+func TestHookLevels(t *testing.T) {
+	require.Equal(t, logrus.AllLevels, logrushook.New().Levels())
+
+	h := &logrushook.Hook{LogLevels: []logrus.Level{logrus.ErrorLevel}}
+	require.Equal(t, []logrus.Level{logrus.ErrorLevel}, h.Levels())
+}
+
+func TestHookSeverity(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	logger.AddHook(&logrushook.Hook{AdjustLevel: true})
+	hook := test.NewLocal(logger)
+
+	logger.WithError(errors.Warn(io.EOF)).Error("degraded")
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.WarnLevel, entry.Level)
+	require.Equal(t, "warning", entry.Data[logrushook.SeverityKey])
+
+	logger.WithError(io.EOF).Warn("failed")
+	entry = hook.LastEntry()
+	require.Equal(t, logrus.ErrorLevel, entry.Level)
+	require.NotContains(t, entry.Data, logrushook.SeverityKey)
+
+	require.Equal(t, logrus.InfoLevel, logrushook.Level(nil))
+	require.Equal(t, logrus.ErrorLevel, logrushook.Level(errors.Critical(io.EOF)))
+}
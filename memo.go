@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type memoKey struct {
+	pc  uintptr
+	msg string
+}
+
+var memo struct {
+	sync.RWMutex
+	errs map[memoKey]error
+}
+
+// memoDisabled is non-zero, if memoization is disabled, see SetMemo.
+var memoDisabled int32
+
+// Memo returns an error with the supplied message, which is created only
+// once per call site: first call records stack trace as New does, and all
+// following calls at the same location with the same message return the
+// same shared instance without any allocation. It's intended for extremely
+// hot paths (e.g. validation), where allocations matter more than unique
+// stack traces.
+//
+// Returned error is shared, so it must be treated as immutable. Memoized
+// errors are never evicted, so Memo must be used with constant messages
+// only. Observers of OnCreate are notified only about the first instance.
+func Memo(text string) error {
+	if atomic.LoadInt32(&memoDisabled) != 0 {
+		return newFundamental(text, 1)
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	key := memoKey{pc: pcs[0], msg: text}
+
+	memo.RLock()
+	err, ok := memo.errs[key]
+	memo.RUnlock()
+	if ok {
+		return err
+	}
+
+	memo.Lock()
+	defer memo.Unlock()
+	if err, ok := memo.errs[key]; ok {
+		return err
+	}
+	if memo.errs == nil {
+		memo.errs = make(map[memoKey]error)
+	}
+	err = newFundamental(text, 1)
+	memo.errs[key] = err
+	return err
+}
+
+// SetMemo enables or disables memoization of Memo. Memoization is enabled by
+// default. While it's disabled, Memo creates new error on each call, exactly
+// as New does, e.g. to get unique stack traces while debugging.
+func SetMemo(enabled bool) {
+	var v int32
+	if !enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&memoDisabled, v)
+}
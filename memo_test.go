@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func validate(name string) error {
+	if name == "" {
+		return errors.Memo("empty name")
+	}
+	return errors.Memo("invalid name")
+}
+
+func TestMemo(t *testing.T) {
+	first := validate("")
+	require.EqualError(t, first, "empty name")
+	require.Equal(t, errors.PkgName+".validate", funcName(errors.Stack(first)[0]))
+
+	require.Same(t, first, validate(""))
+	require.NotSame(t, first, validate("-"))
+	require.Same(t, validate("-"), validate("+"))
+
+	// same message at different call site
+	require.NotSame(t, first, errors.Memo("empty name"))
+
+	allocs := testing.AllocsPerRun(100, func() { _ = validate("") })
+	require.Zero(t, allocs)
+}
+
+func TestSetMemo(t *testing.T) {
+	defer errors.SetMemo(true)
+	errors.SetMemo(false)
+
+	first := validate("")
+	require.EqualError(t, first, "empty name")
+	require.NotSame(t, first, validate(""))
+	require.Equal(t, errors.PkgName+".validate", funcName(errors.Stack(first)[0]))
+}
@@ -0,0 +1,50 @@
+package errors
+
+import "sync/atomic"
+
+const defaultSeparator = ": "
+
+// separator holds separator between messages of wrapper and its cause.
+var separator atomic.Value // string
+
+// ownMessages is non-zero, if wrappers don't repeat message of their cause in
+// Error(), see SetOwnMessages.
+var ownMessages int32
+
+// SetSeparator sets separator, which joins message of wrapper (created by
+// Wrap, WithMessage and so on) and message of its cause, e.g. " → ". Default
+// separator is ": ", empty sep restores it.
+func SetSeparator(sep string) {
+	if sep == "" {
+		sep = defaultSeparator
+	}
+	separator.Store(sep)
+}
+
+func loadSeparator() string {
+	if sep, ok := separator.Load().(string); ok {
+		return sep
+	}
+	return defaultSeparator
+}
+
+// SetOwnMessages enables or disables mode, where Error() of wrappers (created
+// by Wrap, WithMessage and so on) returns only their own message, without
+// message of the cause. Causes are still printed in extended format (%+v).
+// It stops duplication in logs, where each layer logs the error it returns,
+// but makes plain messages less informative, so it's disabled by default.
+func SetOwnMessages(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&ownMessages, v)
+}
+
+// joinMessage joins message of wrapper with message of its cause.
+func joinMessage(msg string, cause error) string {
+	if atomic.LoadInt32(&ownMessages) != 0 {
+		return msg
+	}
+	return msg + loadSeparator() + cause.Error()
+}
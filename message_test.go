@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetSeparator(t *testing.T) {
+	defer errors.SetSeparator("")
+	errors.SetSeparator(" → ")
+
+	err := errors.WithMessage(errors.Wrap(io.EOF, "read"), "load config")
+	require.EqualError(t, err, "load config → read → EOF")
+	require.Regexp(t, "^load config → read → EOF\n", fmt.Sprintf("%+v", err))
+	require.Equal(t, "1. load config; 2. read; 3. EOF", errors.Explain(err))
+
+	errors.SetSeparator("")
+	require.EqualError(t, err, "load config: read: EOF")
+}
+
+func TestSetOwnMessages(t *testing.T) {
+	defer errors.SetOwnMessages(false)
+	errors.SetOwnMessages(true)
+
+	err := errors.WithMessage(errors.Wrap(io.EOF, "read"), "load config")
+	require.EqualError(t, err, "load config")
+	require.Equal(t, "load config", fmt.Sprint(err))
+	require.Regexp(t, "^load config: read: EOF\n", fmt.Sprintf("%+v", err))
+	require.ErrorIs(t, err, io.EOF)
+
+	errors.SetOwnMessages(false)
+	require.EqualError(t, err, "load config: read: EOF")
+}
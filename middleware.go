@@ -0,0 +1,182 @@
+package errors
+
+import (
+	"bufio"
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// HTTPHandlerFunc is an HTTP handler, which returns an error instead of
+// writing error response by itself. Use HTTPMiddleware to serve it.
+type HTTPHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorRenderer writes response for err, which was returned, stored or
+// raised as panic by handler and then remapped by HTTPMiddleware.
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, err error)
+
+// RenderProblem is default ErrorRenderer of HTTPMiddleware: it writes err as
+// problem details, see WriteError.
+func RenderProblem(w http.ResponseWriter, _ *http.Request, err error) { WriteError(w, err) }
+
+// HTTPMiddleware returns constructor of http.Handler, which serves handlers,
+// returning errors. Error of handler is passed through remapper, and then
+// response is written by renderer. Messages of errors, returned by remapper,
+// are treated as public (see WithSafeMessage), other errors are sent with
+// public message only (see WriteError). Panics of handler are recovered and
+// converted into errors (see Recover), except http.ErrAbortHandler, which is
+// re-raised to abort the response, as net/http expects. Errors, which were
+// stored with StoreHTTPError (e.g. by inner middlewares), are handled the
+// same way, if handler itself returned nil.
+//
+// If handler has already written response headers (or hijacked the
+// connection), error response can't be written, so renderer is not called:
+// error is logged into ErrorLog of the server instead (or standard logger,
+// if server has none), the same way as net/http logs panics of handlers. Nil
+// remapper doesn't remap errors, nil renderer means RenderProblem.
+//
+//	mw := errors.HTTPMiddleware(remapper, nil)
+//	mux.Handle("/users/", mw(func(w http.ResponseWriter, r *http.Request) error {
+//		user, err := db.GetUser(r.Context(), r.URL.Path)
+//		if err != nil {
+//			return errors.Wrap(err, "get user")
+//		}
+//		return json.NewEncoder(w).Encode(user)
+//	}))
+func HTTPMiddleware(remapper *Remapper, renderer ErrorRenderer) func(HTTPHandlerFunc) http.Handler {
+	if renderer == nil {
+		renderer = RenderProblem
+	}
+	return func(h HTTPHandlerFunc) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			slot := &httpErrorSlot{}
+			r = r.WithContext(context.WithValue(r.Context(), httpErrorKey{}, slot))
+			tw := &trackingWriter{ResponseWriter: w}
+
+			err := serveHTTP(h, tw, r)
+			if err == nil {
+				err = slot.load()
+			}
+			if err == nil {
+				return
+			}
+			if tw.wroteHeader {
+				logLateError(r, err)
+				return
+			}
+			if remapper != nil {
+				if out := remapper.Remap(err); out != err {
+					// remapper chooses errors for clients, so their
+					// messages are public
+					err = WithSafeMessage(out, out.Error())
+				}
+			}
+			renderer(w, r, err)
+		})
+	}
+}
+
+// serveHTTP calls h and converts its panic into an error.
+func serveHTTP(h HTTPHandlerFunc, w http.ResponseWriter, r *http.Request) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			err = Recover(rec)
+		}
+	}()
+	return h(w, r)
+}
+
+// logLateError logs err, which can't be rendered, since response headers
+// were already written.
+func logLateError(r *http.Request, err error) {
+	const format = "errors: error serving %s %s after response was written: %+v"
+	if srv, ok := r.Context().Value(http.ServerContextKey).(*http.Server); ok && srv.ErrorLog != nil {
+		srv.ErrorLog.Printf(format, r.Method, r.URL.Path, err)
+		return
+	}
+	log.Printf(format, r.Method, r.URL.Path, err)
+}
+
+type httpErrorKey struct{}
+
+type httpErrorSlot struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (s *httpErrorSlot) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// StoreHTTPError stores err in context of request r, which is served by
+// HTTPMiddleware, so it's handled after handler returns. It lets plain
+// http.Handler middlewares, which can't return errors, report them. Stored
+// errors are joined (see Join). StoreHTTPError reports whether r is served by
+// HTTPMiddleware, otherwise err is discarded.
+func StoreHTTPError(r *http.Request, err error) bool {
+	slot, ok := r.Context().Value(httpErrorKey{}).(*httpErrorSlot)
+	if !ok {
+		return false
+	}
+	if err != nil {
+		slot.mu.Lock()
+		slot.err = Join(slot.err, err)
+		slot.mu.Unlock()
+	}
+	return true
+}
+
+// trackingWriter remembers, whether response headers were written.
+type trackingWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *trackingWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *trackingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, if original writer supports it. Response
+// of hijacked connection is considered written.
+func (w *trackingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Push implements http.Pusher, if original writer supports it.
+func (w *trackingWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap returns original writer for http.ResponseController.
+func (w *trackingWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
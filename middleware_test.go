@@ -0,0 +1,140 @@
+package errors_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	errNotFound := errors.WithHTTPStatus(errors.NoStack("not found"), http.StatusNotFound)
+	remapper := errors.NewRemapper(errors.IsRemapper(io.EOF, errNotFound))
+	mw := errors.HTTPMiddleware(remapper, nil)
+
+	serve := func(h errors.HTTPHandlerFunc) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mw(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) error {
+			_, err := io.WriteString(w, "hello")
+			return err
+		})
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "hello", rec.Body.String())
+	})
+
+	t.Run("remapped", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.Wrap(io.EOF, "read user")
+		})
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+		require.Contains(t, rec.Body.String(), `"detail":"not found"`)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		})
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Contains(t, rec.Body.String(), `"detail":"internal error"`)
+		require.NotContains(t, rec.Body.String(), "boom")
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			serve(func(w http.ResponseWriter, r *http.Request) error {
+				panic(http.ErrAbortHandler)
+			})
+		})
+	})
+
+	t.Run("stored", func(t *testing.T) {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) error {
+			require.True(t, errors.StoreHTTPError(r, io.EOF))
+			return nil
+		})
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("written", func(t *testing.T) {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stderr)
+
+		rec := serve(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusAccepted)
+			return io.EOF
+		})
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		require.Empty(t, rec.Body.String())
+		require.Contains(t, buf.String(), "errors: error serving GET / after response was written: EOF")
+	})
+
+	t.Run("pusher", func(t *testing.T) {
+		serve(func(w http.ResponseWriter, r *http.Request) error {
+			p, ok := w.(http.Pusher)
+			require.True(t, ok)
+			require.ErrorIs(t, p.Push("/style.css", nil), http.ErrNotSupported)
+			return nil
+		})
+	})
+
+	require.False(t, errors.StoreHTTPError(httptest.NewRequest(http.MethodGet, "/", nil), io.EOF))
+}
+
+func TestHTTPMiddlewareRenderer(t *testing.T) {
+	var got error
+	mw := errors.HTTPMiddleware(nil, func(w http.ResponseWriter, r *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusTeapot)
+	})
+	rec := httptest.NewRecorder()
+	mw(func(w http.ResponseWriter, r *http.Request) error { return io.EOF }).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusTeapot, rec.Code)
+	require.Equal(t, io.EOF, got)
+}
+
+func TestHTTPMiddlewareHijack(t *testing.T) {
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	h := errors.HTTPMiddleware(nil, nil)(func(w http.ResponseWriter, r *http.Request) error {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 2\r\nConnection: close\r\n\r\nhi")
+		rw.Flush()
+		return io.ErrClosedPipe
+	})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		h.ServeHTTP(w, r)
+	}))
+	srv.Config.ErrorLog = log.New(&buf, "", 0)
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, "hi", string(body))
+
+	<-done
+	require.Contains(t, buf.String(), "errors: error serving GET / after response was written: io: read/write on closed pipe")
+}
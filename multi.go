@@ -0,0 +1,217 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// joined is an error which aggregates multiple errors. Unlike stdlib Join,
+// extended format of joined error prints message and stack trace of every
+// child.
+type joined struct {
+	errs []error
+}
+
+// Join returns an error that wraps the given errors, preserving stack trace
+// of each one. Any nil error values are discarded. Join returns nil if every
+// value in errs is nil.
+//
+// The error message consists of messages of each error, separated by
+// newlines. Extended format (%+v) prints every child with its stack trace.
+func Join(errs ...error) error {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	e := &joined{errs: make([]error, 0, n)}
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+	return e
+}
+
+func (j *joined) Unwrap() []error { return j.errs }
+
+func (j *joined) Error() string {
+	msgs := make([]string, len(j.errs))
+	for i, err := range j.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (j *joined) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, strconv.Itoa(len(j.errs))+" errors occurred:")
+			for i, err := range j.errs {
+				var buf bytes.Buffer
+				formatDetailedVerb(&buf, err, detailVerb(s))
+				child := strings.TrimRight(buf.String(), "\n")
+				io.WriteString(s, "\n  ["+strconv.Itoa(i+1)+"] ")
+				io.WriteString(s, strings.ReplaceAll(child, "\n", "\n      "))
+			}
+			formatFooter(s, j)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, j.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", j.Error())
+	}
+}
+
+// Joined returns errors aggregated by the first multi-error (created by Join
+// or any other error with Unwrap() []error method) in err's chain. If there
+// is no such error, Joined returns nil.
+func Joined(err error) []error {
+	for ; err != nil; err = Unwrap(err) {
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			return m.Unwrap()
+		}
+	}
+	return nil
+}
+
+// CauseAll returns root causes of every branch of err's tree in depth-first
+// order: errors, which don't wrap anything. For err without multi-errors in
+// its chain, it's the same as []error{Cause(err)}. If err is nil, CauseAll
+// returns nil.
+func CauseAll(err error) []error {
+	var res []error
+	walkTree(err, func(e error) {
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			if u.Unwrap() == nil {
+				res = append(res, e)
+			}
+		case interface{ Unwrap() []error }:
+			if len(u.Unwrap()) == 0 {
+				res = append(res, e)
+			}
+		default:
+			res = append(res, e)
+		}
+	})
+	return res
+}
+
+// StackAll returns stack trace of every branch of err's tree: for each path
+// from err to one of its root causes (see CauseAll), the outermost stack
+// trace on the path, as Stack returns it. Branches, which share the same
+// stack trace (recorded above multi-error), are listed once. First element,
+// if any, is always the same as Stack(err).
+//
+// Unlike Stacks, StackAll doesn't include stack traces of inner errors,
+// which are hidden by outer stack traces of their branch.
+func StackAll(err error) []StackTrace {
+	var res []StackTrace
+	stackAll(err, nil, &res)
+	return res
+}
+
+func stackAll(err error, outer StackTrace, res *[]StackTrace) {
+	for ; err != nil; err = Unwrap(err) {
+		if outer == nil {
+			if stack, ok := stackOf(err); ok && len(stack) > 0 {
+				outer = stack
+			}
+		}
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, child := range m.Unwrap() {
+				stackAll(child, outer, res)
+			}
+			return
+		}
+	}
+	if outer == nil {
+		return
+	}
+	for _, stack := range *res {
+		if &stack[0] == &outer[0] {
+			return
+		}
+	}
+	*res = append(*res, outer)
+}
+
+// Stacks returns every stack trace recorded in err's tree, including traces
+// of all errors aggregated by multi-errors. Traces are ordered depth-first,
+// from the outermost error to the innermost one.
+func Stacks(err error) []StackTrace {
+	var res []StackTrace
+	walkTree(err, func(e error) {
+		if stack, ok := stackOf(e); ok && len(stack) > 0 {
+			res = append(res, stack)
+		}
+	})
+	return res
+}
+
+// StackAt returns n-th stack trace of err's tree in the order of Stacks: 0
+// is the outermost trace (the same as Stack returns), 1 is the next one, etc.
+// Negative n counts from the innermost trace, so -1 is usually the trace of
+// the point, where error originated. If there is no such trace, StackAt
+// returns nil.
+func StackAt(err error, n int) StackTrace {
+	stacks := Stacks(err)
+	if n < 0 {
+		n += len(stacks)
+	}
+	if n < 0 || n >= len(stacks) {
+		return nil
+	}
+	return stacks[n]
+}
+
+// Walk calls fn for every error in err's tree in depth-first order: for each
+// error in the chain, starting with err itself, and for each branch of
+// multi-errors (errors with Unwrap() []error method). If fn returns false,
+// Walk stops traversal.
+func Walk(err error, fn func(error) bool) {
+	walk(err, fn)
+}
+
+// walk is Walk, which reports whether traversal wasn't stopped.
+func walk(err error, fn func(error) bool) bool {
+	for err != nil {
+		err = skipAdopted(err)
+		if !fn(err) {
+			return false
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				if !walk(child, fn) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+	return true
+}
+
+// walkTree calls fn for every error in err's tree in depth-first order.
+func walkTree(err error, fn func(error)) {
+	walk(err, func(e error) bool {
+		fn(e)
+		return true
+	})
+}
@@ -0,0 +1,147 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestJoinNil(t *testing.T) {
+	require.Nil(t, errors.Join())
+	require.Nil(t, errors.Join(nil, nil))
+}
+
+func TestJoin(t *testing.T) {
+	err1 := errors.New("first")
+	err2 := errors.Wrap(io.EOF, "second")
+	err := errors.Join(err1, nil, err2)
+
+	require.Equal(t, "first\nsecond: EOF", err.Error())
+	require.True(t, stderrors.Is(err, io.EOF))
+	require.True(t, stderrors.Is(err, err1))
+	require.Equal(t, []error{err1, err2}, errors.Joined(err))
+	require.Equal(t, []error{err1, err2}, errors.Joined(errors.Wrap(err, "batch")))
+	require.Nil(t, errors.Joined(err1))
+
+	stacks := errors.Stacks(err)
+	require.Len(t, stacks, 2)
+	require.Equal(t, errors.Stack(err1), stacks[0])
+	require.Equal(t, errors.Stack(err2), stacks[1])
+}
+
+func TestStackAt(t *testing.T) {
+	origin := errors.New("origin")
+	rewrapped := errors.WithStack(errors.Wrap(origin, "read"))
+	err := errors.WrapHere(rewrapped, "handle")
+
+	tests := []struct {
+		n    int
+		want errors.StackTrace
+	}{
+		{0, errors.Stack(err)},
+		{1, errors.Stack(rewrapped)},
+		{2, errors.Stack(origin)},
+		{3, nil},
+		{-1, errors.Stack(origin)},
+		{-3, errors.Stack(err)},
+		{-4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			require.Equal(t, tt.want, errors.StackAt(err, tt.n))
+		})
+	}
+
+	require.Nil(t, errors.StackAt(nil, 0))
+	require.Nil(t, errors.StackAt(io.EOF, -1))
+}
+
+func TestJoinFormat(t *testing.T) {
+	err := errors.Join(errors.New("first"), errors.WithMessage(io.EOF, "second"))
+
+	want := "2 errors occurred:\n" +
+		"  \\[1\\] first\n" +
+		"      " + errors.PkgName + ".TestJoinFormat\n" +
+		"      \t.+/" + errors.PkgNameRaw + "/multi_test.go:\\d+\n" +
+		"(?s).*" +
+		"  \\[2\\] second: EOF"
+
+	got := fmt.Sprintf("%+v", err)
+	if !regexpMatch(want, got) {
+		t.Errorf("%%+v:\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	first := errors.New("first")
+	second := errors.Wrap(io.EOF, "second")
+	err := errors.Wrap(errors.Join(first, second), "joined")
+
+	var got []error
+	errors.Walk(err, func(e error) bool {
+		got = append(got, e)
+		return true
+	})
+	require.Equal(t, err, got[0])
+	require.Equal(t, io.EOF, got[len(got)-1])
+	require.Contains(t, got, first)
+	require.Contains(t, got, second)
+	require.Less(t, indexOf(got, first), indexOf(got, second))
+
+	var visited int
+	errors.Walk(err, func(e error) bool {
+		visited++
+		return e != first
+	})
+	require.Equal(t, indexOf(got, first)+1, visited)
+
+	errors.Walk(nil, func(error) bool {
+		t.Fatal("unexpected call")
+		return true
+	})
+}
+
+func indexOf(errs []error, target error) int {
+	for i, e := range errs {
+		if e == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// foreignMulti is a multi-error of another package.
+type foreignMulti []error
+
+func (m foreignMulti) Error() string   { return "multiple errors" }
+func (m foreignMulti) Unwrap() []error { return m }
+
+func TestCauseAll(t *testing.T) {
+	require.Nil(t, errors.CauseAll(nil))
+	require.Equal(t, []error{io.EOF}, errors.CauseAll(errors.Wrap(io.EOF, "read")))
+
+	first := errors.New("first")
+	err := errors.Wrap(errors.Join(first, errors.Wrap(io.EOF, "second"), foreignMulti{io.ErrClosedPipe}), "joined")
+	require.Equal(t, []error{first, io.EOF, io.ErrClosedPipe}, errors.CauseAll(err))
+}
+
+func TestStackAll(t *testing.T) {
+	require.Nil(t, errors.StackAll(nil))
+	require.Nil(t, errors.StackAll(io.EOF))
+
+	first := errors.New("first")
+	second := errors.New("second")
+	joined := errors.Join(io.EOF, first, errors.Wrap(second, "wrapped"))
+	require.Equal(t, errors.Stack(first), errors.Stack(joined))
+	require.Equal(t, []errors.StackTrace{errors.Stack(first), errors.Stack(second)}, errors.StackAll(joined))
+
+	wrapped := errors.WrapHere(joined, "here")
+	require.Equal(t, errors.Stack(wrapped), errors.StackAll(wrapped)[0])
+	require.Len(t, errors.StackAll(wrapped), 1)
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type observer struct {
+	fn func(err error, st StackTrace)
+}
+
+var (
+	observersMu sync.Mutex
+	observers   atomic.Value // []*observer
+)
+
+// OnCreate registers fn, which is called whenever New, Errorf, Wrap,
+// WithStack or other constructor of this package records a new stack trace.
+// fn receives created error and its stack trace, so it can e.g. increment
+// metrics by call site (see Fingerprint) without touching every return
+// statement. Wrapping of an error, which already has a stack trace, records
+// only the wrap point, so fn is not called again and each error is counted
+// once, no matter how many times it's wrapped.
+//
+// fn is called synchronously in the goroutine, which creates an error, so it
+// must be fast and safe for concurrent use. OnCreate returns function, which
+// unregisters fn.
+func OnCreate(fn func(err error, st StackTrace)) (remove func()) {
+	o := &observer{fn: fn}
+
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	old, _ := observers.Load().([]*observer)
+	observers.Store(append(old[:len(old):len(old)], o))
+
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+		old, _ := observers.Load().([]*observer)
+		res := make([]*observer, 0, len(old))
+		for _, other := range old {
+			if other != o {
+				res = append(res, other)
+			}
+		}
+		observers.Store(res)
+	}
+}
+
+// created notifies observers about err, if err has its own stack trace, and
+// returns it. If timestamps, goroutine labels or build info are enabled (see
+// SetTimestamps, SetGoroutineLabels and SetBuildInfo), err is annotated with
+// them first.
+func created(err error) error {
+	stack, recorded := stackOf(err)
+	if atomic.LoadInt32(&timestamps) != 0 {
+		if _, ok := Time(err); !ok {
+			err = &withTime{cause: err, time: time.Now()}
+		}
+	}
+	if atomic.LoadInt32(&goroutineLabels) != 0 && !hasLabels(err) {
+		err = &withLabels{cause: err, labels: withGoroutine(map[string]string{})}
+	}
+	if info := loadBuildInfo(); info != nil {
+		if _, ok := BuildInfoOf(err); !ok {
+			err = &withBuildInfo{cause: err, info: *info}
+		}
+	}
+
+	list, _ := observers.Load().([]*observer)
+	if len(list) == 0 || !recorded || stack == nil {
+		return err
+	}
+	for _, o := range list {
+		o.fn(err, stack)
+	}
+	return err
+}
@@ -0,0 +1,83 @@
+package errors_test
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestOnCreate(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		got   []error
+		sites []string
+	)
+	remove := errors.OnCreate(func(err error, st errors.StackTrace) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, err)
+		sites = append(sites, funcName(st[0]))
+	})
+
+	errs := []error{
+		errors.New("new"),
+		errors.Errorf("errorf"),
+		errors.WithStack(io.EOF),
+		errors.Wrap(io.EOF, "wrap"),
+		errors.NewT("new %v", errors.Arg("t", 1)),
+		errors.Const("const").Here(),
+	}
+	remove()
+	_ = errors.New("after remove")
+
+	require.Equal(t, errs, got)
+	for _, site := range sites {
+		require.Equal(t, errors.PkgName+".TestOnCreate", site)
+	}
+}
+
+func TestOnCreateWrapped(t *testing.T) {
+	var got []errors.StackTrace
+	remove := errors.OnCreate(func(_ error, st errors.StackTrace) { got = append(got, st) })
+	defer remove()
+
+	err := errors.New("new")
+	err = errors.Wrap(err, "wrap")
+	err = errors.Wrapf(err, "wrapf %d", 1)
+
+	require.Len(t, got, 1)
+	require.Equal(t, errors.Stack(err), got[0])
+}
+
+func TestOnCreateRemove(t *testing.T) {
+	var a, b int
+	removeA := errors.OnCreate(func(error, errors.StackTrace) { a++ })
+	removeB := errors.OnCreate(func(error, errors.StackTrace) { b++ })
+
+	_ = errors.New("error")
+	removeA()
+	_ = errors.New("error")
+	removeB()
+	_ = errors.New("error")
+
+	require.Equal(t, 1, a)
+	require.Equal(t, 2, b)
+}
+
+func TestNoStackCreated(t *testing.T) {
+	notified := 0
+	defer errors.OnCreate(func(error, errors.StackTrace) { notified++ })()
+	errors.SetTimestamps(true)
+	defer errors.SetTimestamps(false)
+
+	for _, err := range []error{errors.NoStack("cache miss"), errors.NoStackf("cache miss: %d", 42)} {
+		require.Nil(t, errors.Stack(err))
+		_, ok := errors.Time(err)
+		require.True(t, ok)
+	}
+	require.Zero(t, notified)
+}
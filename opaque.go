@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// opaque hides the chain of wrapped error from Is, As and Unwrap, but still
+// keeps it inside for formatting and stack extraction.
+type opaque struct {
+	err error
+}
+
+// Opaque returns an error with the same message and stack trace as err, but
+// which doesn't expose any of underlying errors: Is, As, Unwrap and Cause will
+// not see anything behind the returned value. It's useful on API boundaries,
+// where callers must not depend on internal error types.
+// If err is nil, Opaque returns nil.
+func Opaque(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &opaque{err: err}
+}
+
+func (o *opaque) Error() string          { return o.err.Error() }
+func (o *opaque) stackTrace() StackTrace { return Stack(o.err) }
+func (o *opaque) message() string        { return o.err.Error() }
+
+func (o *opaque) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatDetailed(s, o.err)
+			formatFooter(s, o.err)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, o.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", o.Error())
+	}
+}
@@ -0,0 +1,31 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestOpaqueNil(t *testing.T) {
+	require.Nil(t, errors.Opaque(nil))
+}
+
+func TestOpaque(t *testing.T) {
+	inner := errors.Wrap(io.EOF, "read failed")
+	err := errors.Opaque(inner)
+
+	require.Equal(t, "read failed: EOF", err.Error())
+	require.False(t, stderrors.Is(err, io.EOF))
+	require.Nil(t, stderrors.Unwrap(err))
+	require.Equal(t, err, errors.Cause(err))
+	require.Equal(t, errors.Stack(inner), errors.Stack(err))
+	require.Equal(t, fmt.Sprintf("%+v", inner), fmt.Sprintf("%+v", err))
+	require.True(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "read failed: EOF\n"))
+	require.Equal(t, `"read failed: EOF"`, fmt.Sprintf("%q", err))
+}
@@ -0,0 +1,104 @@
+package errors
+
+import "sync/atomic"
+
+// Option configures stack trace capture of errors created with options.
+type Option func(*options)
+
+type options struct {
+	depth   int
+	skip    uint
+	keep    FrameMatcher
+	noStack bool
+}
+
+// Depth sets maximum depth of recorded stack trace. It overrides default
+// depth, configured by SetDefaultDepth.
+func Depth(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.depth = n
+		}
+	}
+}
+
+// Skip skips n additional frames of the stack trace. It allows library
+// wrappers to hide themselves from recorded traces.
+func Skip(n uint) Option {
+	return func(o *options) { o.skip = n }
+}
+
+// FilterFrames keeps only frames of recorded stack trace, for which keep
+// returns true.
+func FilterFrames(keep FrameMatcher) Option {
+	return func(o *options) { o.keep = keep }
+}
+
+// NoStackTrace disables stack capture, so errors are created as cheap as
+// with NoStack. Unlike NoStack, it can be toggled per call site, e.g. for
+// hot paths, where stack capture dominates the cost of errors:
+//
+//	opts := []errors.Option{errors.Depth(8)}
+//	if hot {
+//		opts = append(opts, errors.NoStackTrace())
+//	}
+//	return errors.NewWithOptions("cache miss", opts...)
+//
+// Stack traces are symbolized lazily anyway: recorded frames are raw program
+// counters, resolved only when trace is formatted or inspected.
+func NoStackTrace() Option {
+	return func(o *options) { o.noStack = true }
+}
+
+func newOptions(opts []Option) options {
+	o := options{depth: int(atomic.LoadInt32(&stackDepth))}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewWithOptions returns an error with the supplied message. Stack trace is
+// recorded at the point NewWithOptions was called, according to the options.
+func NewWithOptions(text string, opts ...Option) error {
+	return created(&fundamental{
+		msg:   text,
+		stack: newOptions(opts).callers(1),
+	})
+}
+
+func (o options) callers(extraSkip uint) StackTrace {
+	if o.noStack {
+		return nil
+	}
+	stack := callersDepth(1+extraSkip+o.skip, o.depth)
+	if o.keep != nil {
+		stack = stack.Filter(o.keep)
+	}
+	return stack
+}
+
+// Callers returns stack trace of the calling goroutine, starting from the
+// caller of Callers. skip is the number of additional frames to skip: 1
+// means caller of the caller, etc. Depth of the trace can be configured with
+// options. Returned trace is compatible with all formatters of this package.
+func Callers(skip int, opts ...Option) StackTrace {
+	if skip < 0 {
+		skip = 0
+	}
+	return newOptions(opts).callers(1 + uint(skip))
+}
+
+// Caller returns single frame of the calling goroutine's stack: skip is the
+// number of frames to skip, 0 means caller of Caller. If there is no such
+// frame, Caller returns zero Frame.
+func Caller(skip int) Frame {
+	if skip < 0 {
+		skip = 0
+	}
+	stack := callersDepth(1+uint(skip), 1)
+	if len(stack) == 0 {
+		return 0
+	}
+	return stack[0]
+}
@@ -0,0 +1,81 @@
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func recursiveNew(depth int, opts ...errors.Option) error {
+	if depth == 0 {
+		return errors.NewWithOptions("error", opts...)
+	}
+	return recursiveNew(depth-1, opts...)
+}
+
+func newHelper() error { return errors.NewWithOptions("error", errors.Skip(1)) }
+
+func TestNewWithOptions(t *testing.T) {
+	require.Len(t, errors.Stack(recursiveNew(100)), 32)
+	require.Len(t, errors.Stack(recursiveNew(100, errors.Depth(5))), 5)
+	require.Len(t, errors.Stack(recursiveNew(100, errors.Depth(64))), 64)
+
+	require.Equal(t, errors.PkgName+".TestNewWithOptions", funcName(errors.Stack(newHelper())[0]))
+}
+
+func TestSetDefaultDepth(t *testing.T) {
+	defer errors.SetDefaultDepth(0)
+
+	errors.SetDefaultDepth(100)
+	require.Len(t, errors.Stack(recursiveNew(200)), 100)
+
+	errors.SetDefaultDepth(0)
+	require.Len(t, errors.Stack(recursiveNew(200)), 32)
+}
+
+func callersHelper(skip int, opts ...errors.Option) errors.StackTrace {
+	return errors.Callers(skip, opts...)
+}
+
+func TestCallers(t *testing.T) {
+	require.Equal(t, errors.PkgName+".callersHelper", funcName(callersHelper(0)[0]))
+	require.Equal(t, errors.PkgName+".TestCallers", funcName(callersHelper(1)[0]))
+	require.Equal(t, errors.PkgName+".TestCallers", funcName(callersHelper(-1)[1]))
+	require.Len(t, callersHelper(0, errors.Depth(1)), 1)
+
+	stack := callersHelper(0, errors.FilterFrames(errors.MatchFunc(errors.PkgName)))
+	require.Len(t, stack, 2)
+	require.Equal(t, errors.PkgName+".TestCallers", funcName(stack[1]))
+}
+
+func TestCaller(t *testing.T) {
+	require.Equal(t, errors.PkgName+".TestCaller", funcName(errors.Caller(0)))
+	require.Equal(t, "testing.tRunner", funcName(errors.Caller(1)))
+	require.Equal(t, errors.Frame(0), errors.Caller(1000))
+}
+
+func TestNewWithOptionsFilter(t *testing.T) {
+	err := errors.NewWithOptions("error", errors.FilterFrames(func(f errors.Frame) bool {
+		return funcName(f) != errors.PkgName+".TestNewWithOptionsFilter"
+	}))
+	require.Equal(t, "testing.tRunner", funcName(errors.Stack(err)[0]))
+}
+
+func TestNoStackTrace(t *testing.T) {
+	notified := 0
+	defer errors.OnCreate(func(error, errors.StackTrace) { notified++ })()
+	errors.SetTimestamps(true)
+	defer errors.SetTimestamps(false)
+
+	err := errors.NewWithOptions("cache miss", errors.Depth(8), errors.NoStackTrace())
+	require.EqualError(t, err, "cache miss")
+	require.Nil(t, errors.Stack(err))
+	require.False(t, errors.SampledOut(err))
+	require.Zero(t, notified)
+	_, ok := errors.Time(err)
+	require.True(t, ok)
+
+	require.Nil(t, callersHelper(0, errors.NoStackTrace()))
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Origin returns the innermost meaningful frame of err: the newest frame of
+// the innermost stack trace in err's tree (see StackAt), which doesn't belong
+// to runtime or testing packages. It's the function, where failure
+// originated, so it can be used to tag metrics and alerts without formatting
+// the whole trace. If err has no stack trace, Origin returns false.
+func Origin(err error) (Frame, bool) {
+	for _, f := range StackAt(err, -1) {
+		if !isRuntimeFrame(f) {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// OriginString returns Origin of err in the form of "pkg.Func(file.go:42)".
+// If err has no stack trace, OriginString returns empty string.
+func OriginString(err error) string {
+	f, ok := Origin(err)
+	if !ok {
+		return ""
+	}
+	return shortFrame(f)
+}
+
+// shortFrame returns f in the form of "pkg.Func(file.go:42)".
+func shortFrame(f Frame) string {
+	file, line, name := f.FuncInfo()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return name + "(" + path.Base(file) + ":" + strconv.Itoa(line) + ")"
+}
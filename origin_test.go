@@ -0,0 +1,27 @@
+package errors_test
+
+import (
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func originFailure() error { return errors.New("boom") }
+
+func TestOrigin(t *testing.T) {
+	_, ok := errors.Origin(io.EOF)
+	require.False(t, ok)
+	require.Empty(t, errors.OriginString(io.EOF))
+	require.Empty(t, errors.OriginString(nil))
+
+	err := errors.WrapHere(originFailure(), "outer")
+	f, ok := errors.Origin(err)
+	require.True(t, ok)
+	require.Equal(t, errors.PkgName+".originFailure", funcName(f))
+
+	require.Regexp(t, regexp.MustCompile(`^errors_test\.originFailure\(origin_test\.go:\d+\)$`), errors.OriginString(err))
+}
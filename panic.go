@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Recover converts value, returned by recover(), into an error with stack
+// trace of the goroutine at the point, where panic happened (not where it
+// was recovered). If recovered value is an error, it's kept in the chain, so
+// Is and As still work. Like errors of New, recovered errors are reported to
+// OnCreate observers and get timestamps, labels and build info, if enabled.
+// If recovered is nil, Recover returns nil.
+//
+//	defer func() {
+//		if err := errors.Recover(recover()); err != nil {
+//			log.Printf("%+v", err)
+//		}
+//	}()
+func Recover(recovered interface{}) error {
+	if recovered == nil {
+		return nil
+	}
+	stack := panicStack(callers(0))
+
+	if err, ok := recovered.(error); ok {
+		return created(&withStack{
+			&withMessage{cause: err, msg: "panic"},
+			stack,
+		})
+	}
+	return created(&fundamental{
+		msg:   fmt.Sprintf("panic: %v", recovered),
+		stack: stack,
+	})
+}
+
+// WrapPanic calls fn and converts its panic, if any, into an error (see
+// Recover). Otherwise, error of fn is returned as is.
+func WrapPanic(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = Recover(r)
+		}
+	}()
+	return fn()
+}
+
+// panicStack trims frames of deferred calls and panic machinery from the
+// stack, captured during panicking, so the panic site becomes the first
+// frame. If stack wasn't captured during panic, only the frame of the
+// function, which captured the stack, is trimmed.
+func panicStack(stack StackTrace) StackTrace {
+	for i, f := range stack {
+		if _, _, name := f.FuncInfo(); name != "runtime.gopanic" {
+			continue
+		}
+		for i++; i < len(stack); i++ {
+			if _, _, name := stack[i].FuncInfo(); !strings.HasPrefix(name, "runtime.") {
+				break
+			}
+		}
+		return stack[i:]
+	}
+	if len(stack) > 0 {
+		return stack[1:]
+	}
+	return stack
+}
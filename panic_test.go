@@ -0,0 +1,76 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func panicking(v interface{}) { panic(v) }
+
+func nilDeref() int {
+	var p *int
+	return *p
+}
+
+func TestRecoverNil(t *testing.T) {
+	require.Nil(t, errors.Recover(nil))
+	require.NoError(t, errors.WrapPanic(func() error { return nil }))
+	require.Equal(t, io.EOF, errors.WrapPanic(func() error { return io.EOF }))
+}
+
+func TestWrapPanic(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       func() error
+		wantMsg  string
+		wantFunc string
+		wantIs   error
+	}{{
+		name:     "value",
+		fn:       func() error { panicking("boom"); return nil },
+		wantMsg:  "panic: boom",
+		wantFunc: errors.PkgName + ".panicking",
+	}, {
+		name:     "error",
+		fn:       func() error { panicking(io.EOF); return nil },
+		wantMsg:  "panic: EOF",
+		wantFunc: errors.PkgName + ".panicking",
+		wantIs:   io.EOF,
+	}, {
+		name:     "runtime error",
+		fn:       func() error { nilDeref(); return nil },
+		wantMsg:  "panic: runtime error: invalid memory address or nil pointer dereference",
+		wantFunc: errors.PkgName + ".nilDeref",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.WrapPanic(tt.fn)
+			require.Equal(t, tt.wantMsg, err.Error())
+			require.Equal(t, tt.wantFunc, funcName(errors.Stack(err)[0]))
+			if tt.wantIs != nil {
+				require.True(t, stderrors.Is(err, tt.wantIs))
+			}
+		})
+	}
+}
+
+func TestRecoverCreated(t *testing.T) {
+	var got []errors.StackTrace
+	defer errors.OnCreate(func(_ error, st errors.StackTrace) { got = append(got, st) })()
+	errors.SetTimestamps(true)
+	defer errors.SetTimestamps(false)
+
+	for _, v := range []interface{}{"boom", io.EOF} {
+		err := errors.WrapPanic(func() error { panicking(v); return nil })
+		_, ok := errors.Time(err)
+		require.True(t, ok)
+		require.Equal(t, errors.Stack(err), got[len(got)-1])
+	}
+	require.Len(t, got, 2)
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"path"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PathMode defines how source file paths are rendered in extended (%+v)
+// format of stack traces.
+type PathMode int32
+
+const (
+	// PathFull renders full path of source file, as it was at compile time.
+	PathFull PathMode = iota
+	// PathTrim renders path relative to the module root (for main module
+	// and modules in module cache) or GOROOT (for standard library), e.g.
+	// internal/foo/bar.go instead of /home/ci/build/internal/foo/bar.go.
+	PathTrim
+	// PathBase renders only file name.
+	PathBase
+)
+
+var pathMode int32
+
+// SetPathMode sets how source file paths are rendered in extended format of
+// stack traces. Default mode is PathFull.
+func SetPathMode(mode PathMode) { atomic.StoreInt32(&pathMode, int32(mode)) }
+
+// displayPath returns path of the file of frame f in current path mode. file
+// and name are resolved location and function name of f, name is used to
+// detect module root.
+func displayPath(f Frame, file, name string) string {
+	switch PathMode(atomic.LoadInt32(&pathMode)) {
+	case PathTrim:
+		return trimPath(f, file, name)
+	case PathBase:
+		return path.Base(file)
+	default:
+		return file
+	}
+}
+
+// mainModule holds path and detected root directory of the main module, and
+// import path of the main package.
+var mainModule struct {
+	once sync.Once
+	path string
+	pkg  string
+
+	mu   sync.RWMutex
+	root string
+}
+
+func trimPath(f Frame, file, name string) string {
+	mainModule.once.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			mainModule.path = info.Main.Path
+			mainModule.pkg = info.Path
+		}
+	})
+
+	if root := moduleRoot(file, name, !f.isSynthetic()); root != "" && strings.HasPrefix(file, root+"/") {
+		return file[len(root)+1:]
+	}
+	if i := strings.Index(file, "/pkg/mod/"); i >= 0 {
+		return file[i+len("/pkg/mod/"):]
+	}
+	if goroot := runtime.GOROOT(); goroot != "" && strings.HasPrefix(file, goroot+"/src/") {
+		return file[len(goroot)+len("/src/"):]
+	}
+	if p := mainModule.path; p != "" {
+		if i := strings.Index(file, "/"+p+"/"); i >= 0 {
+			return file[i+len(p)+2:]
+		}
+	}
+	return file
+}
+
+// moduleRoot returns root directory of the main module. Root is detected
+// from the first frame of main module's package: directory of the file
+// without relative path of the package is the module root. Only frames of
+// current binary may be used for detection (detect is false for synthetic
+// ones), since paths of decoded frames can be arbitrary.
+func moduleRoot(file, name string, detect bool) string {
+	mainModule.mu.RLock()
+	root := mainModule.root
+	mainModule.mu.RUnlock()
+	if root != "" || !detect {
+		return root
+	}
+
+	root = detectModuleRoot(mainModule.path, mainModule.pkg, file, name)
+	if root == "" {
+		return ""
+	}
+	mainModule.mu.Lock()
+	mainModule.root = root
+	mainModule.mu.Unlock()
+	return root
+}
+
+// detectModuleRoot returns root directory of module modPath, derived from
+// file of function name, or empty string, if function doesn't belong to the
+// module. mainPkg is import path of the main package, since functions of
+// main package are named as "main.F".
+func detectModuleRoot(modPath, mainPkg, file, name string) string {
+	if modPath == "" {
+		return ""
+	}
+	pkg, _ := splitFuncName(name)
+	if pkg == "main" {
+		pkg = mainPkg
+	}
+	// external test packages have _test suffix in package path
+	pkg = strings.TrimSuffix(pkg, "_test")
+	if pkg != modPath && !strings.HasPrefix(pkg, modPath+"/") {
+		return ""
+	}
+	dir := path.Dir(file)
+	rel := pkg[len(modPath):]
+	if !strings.HasSuffix(dir, rel) {
+		return ""
+	}
+	return strings.TrimSuffix(dir, rel)
+}
+
+// splitFuncName splits full function name into package path and function
+// name, e.g. "github.com/a/b.(*T).F" into "github.com/a/b" and "(*T).F".
+func splitFuncName(name string) (pkg, fn string) {
+	i := strings.LastIndex(name, "/")
+	j := strings.Index(name[i+1:], ".")
+	if j < 0 {
+		return "", name
+	}
+	return name[:i+1+j], name[i+1+j+1:]
+}
@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectModuleRoot(t *testing.T) {
+	const mod = "github.com/a/b"
+	tests := []struct {
+		name, file, fn string
+		want           string
+	}{
+		{"root package", "/src/b/b.go", "github.com/a/b.F", "/src/b"},
+		{"subpackage", "/src/b/internal/c/c.go", "github.com/a/b/internal/c.(*T).F", "/src/b"},
+		{"external test", "/src/b/b_test.go", "github.com/a/b_test.TestF", "/src/b"},
+		{"external test of subpackage", "/src/b/c/c_test.go", "github.com/a/b/c_test.TestF", "/src/b"},
+		{"main", "/src/b/cmd/tool/main.go", "main.main", "/src/b"},
+		{"other module", "/src/bc/bc.go", "github.com/a/bc.F", ""},
+		{"dependency", "/go/pkg/mod/github.com/x/y@v1.0.0/y.go", "github.com/x/y.F", ""},
+		{"moved file", "/src/b/other/c.go", "github.com/a/b/c.F", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, detectModuleRoot(mod, mod+"/cmd/tool", tt.file, tt.fn))
+		})
+	}
+}
+
+func TestModuleRoot(t *testing.T) {
+	mainModule.mu.Lock()
+	saved := mainModule.root
+	mainModule.root = ""
+	mainModule.mu.Unlock()
+	defer func() {
+		mainModule.mu.Lock()
+		mainModule.root = saved
+		mainModule.mu.Unlock()
+	}()
+
+	// synthetic frames must not poison detected root
+	var fake Frame
+	require.NoError(t, fake.UnmarshalText([]byte(PkgNameRaw+".F /evil/f.go:1")))
+	file, _, name := fake.FuncInfo()
+	trimPath(fake, file, name)
+	mainModule.mu.RLock()
+	require.Empty(t, mainModule.root)
+	mainModule.mu.RUnlock()
+
+	_, here, _, _ := runtime.Caller(0)
+	f := callers(0)[0]
+	file, _, name = f.FuncInfo()
+	require.Equal(t, "path_internal_test.go", trimPath(f, file, name))
+	mainModule.mu.RLock()
+	require.Equal(t, filepath.ToSlash(filepath.Dir(here)), mainModule.root)
+	mainModule.mu.RUnlock()
+}
@@ -0,0 +1,38 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetPathMode(t *testing.T) {
+	defer errors.SetPathMode(errors.PathFull)
+	f := errors.Stack(errors.New("error"))[0]
+
+	tests := []struct {
+		mode errors.PathMode
+		want string
+	}{
+		{errors.PathFull, `^` + errors.PkgName + `\.TestSetPathMode\n\t/.+/path_test\.go:\d+$`},
+		{errors.PathTrim, `^` + errors.PkgName + `\.TestSetPathMode\n\tpath_test\.go:\d+$`},
+		{errors.PathBase, `^` + errors.PkgName + `\.TestSetPathMode\n\tpath_test\.go:\d+$`},
+	}
+
+	for _, tt := range tests {
+		errors.SetPathMode(tt.mode)
+		require.Regexp(t, tt.want, fmt.Sprintf("%+v", f))
+	}
+
+	errors.SetPathMode(errors.PathTrim)
+	var stdFrame errors.Frame
+	require.NoError(t, stdFrame.UnmarshalText([]byte("fmt.Println /usr/local/go/src/fmt/print.go:42")))
+	require.Regexp(t, `^fmt.Println\n\t(fmt/print\.go|/usr/local/go/src/fmt/print\.go):42$`, fmt.Sprintf("%+v", stdFrame))
+
+	var modFrame errors.Frame
+	require.NoError(t, modFrame.UnmarshalText([]byte("github.com/a/b.F /home/u/go/pkg/mod/github.com/a/b@v1.0.0/b.go:7")))
+	require.Equal(t, "github.com/a/b.F\n\tgithub.com/a/b@v1.0.0/b.go:7", fmt.Sprintf("%+v", modFrame))
+}
@@ -0,0 +1,164 @@
+// Package pkgerrors is a drop-in replacement of github.com/pkg/errors, built
+// on top of github.com/quenbyako/errors. It mirrors exported API of
+// github.com/pkg/errors exactly, including types: StackTrace and Frame are
+// aliases of github.com/pkg/errors types, so third-party code, which looks
+// for
+//
+//	interface{ StackTrace() errors.StackTrace }
+//
+// or
+//
+//	interface{ Cause() error }
+//
+// keeps working with errors created by this package. Large codebases can
+// migrate by changing import path only:
+//
+//	import errors "github.com/quenbyako/errors/pkgerrors"
+//
+// Errors created by this package are also errors of
+// github.com/quenbyako/errors: they can be inspected with its Stack, Fields,
+// KindOf and so on. The only difference in behavior is Wrap of an error,
+// which already has stack trace: as github.com/quenbyako/errors.Wrap does,
+// it records only the frame, where error was wrapped, and StackTrace returns
+// the original trace.
+package pkgerrors
+
+import (
+	"fmt"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/quenbyako/errors"
+)
+
+// Frame represents a program counter inside a stack frame.
+type Frame = pkgerrors.Frame
+
+// StackTrace is stack of Frames from innermost (newest) to outermost (oldest).
+type StackTrace = pkgerrors.StackTrace
+
+// New returns an error with the supplied message.
+// New also records the stack trace at the point it was called.
+func New(message string) error {
+	return &fundamental{errors.NewSkip(message, 1)}
+}
+
+// Errorf formats according to a format specifier and returns the string
+// as a value that satisfies error.
+// Errorf also records the stack trace at the point it was called.
+func Errorf(format string, args ...interface{}) error {
+	return &fundamental{errors.NewSkip(fmt.Sprintf(format, args...), 1)}
+}
+
+// WithStack annotates err with a stack trace at the point WithStack was called.
+// If err is nil, WithStack returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{errors.WithStackSkip(err, 1), err}
+}
+
+// Wrap returns an error annotating err with a stack trace
+// at the point Wrap is called, and the supplied message.
+// If err is nil, Wrap returns nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{errors.WrapSkip(err, message, 1), err}
+}
+
+// Wrapf returns an error annotating err with a stack trace
+// at the point Wrapf is called, and the format specifier.
+// If err is nil, Wrapf returns nil.
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{errors.WrapSkip(err, fmt.Sprintf(format, args...), 1), err}
+}
+
+// WithMessage annotates err with a new message.
+// If err is nil, WithMessage returns nil.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &withMessage{errors.WithMessage(err, message), err}
+}
+
+// WithMessagef annotates err with the format specifier.
+// If err is nil, WithMessagef returns nil.
+func WithMessagef(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withMessage{errors.WithMessagef(err, format, args...), err}
+}
+
+// Cause returns the underlying cause of the error, if possible.
+// An error value has a cause if it implements the following
+// interface:
+//
+//	type causer interface {
+//	       Cause() error
+//	}
+//
+// If the error does not implement Cause, the original error will
+// be returned. If the error is nil, nil will be returned without further
+// investigation.
+func Cause(err error) error { return pkgerrors.Cause(err) }
+
+// Is reports whether any error in err's chain matches target.
+func Is(err, target error) bool { return errors.Is(err, target) }
+
+// As finds the first error in err's chain that matches target, and if so,
+// sets target to that error value and returns true.
+func As(err error, target interface{}) bool { return errors.As(err, target) }
+
+// Unwrap returns the result of calling the Unwrap method on err, if err's
+// type contains an Unwrap method returning error.
+// Otherwise, Unwrap returns nil.
+func Unwrap(err error) error { return errors.Unwrap(err) }
+
+// stackTrace converts stack trace of err into github.com/pkg/errors type.
+// Both packages represent frames as program counter + 1, so frames are
+// converted as is.
+func stackTrace(err error) StackTrace {
+	stack := errors.Stack(err)
+	res := make(StackTrace, len(stack))
+	for i, f := range stack {
+		res[i] = Frame(f)
+	}
+	return res
+}
+
+// fundamental is an error without cause, created by New or Errorf.
+type fundamental struct{ err error }
+
+func (f *fundamental) Error() string                 { return f.err.Error() }
+func (f *fundamental) Unwrap() error                 { return f.err }
+func (f *fundamental) StackTrace() StackTrace        { return stackTrace(f.err) }
+func (f *fundamental) Format(s fmt.State, verb rune) { f.err.(fmt.Formatter).Format(s, verb) }
+
+type withStack struct {
+	err   error
+	cause error
+}
+
+func (w *withStack) Error() string                 { return w.err.Error() }
+func (w *withStack) Unwrap() error                 { return w.err }
+func (w *withStack) Cause() error                  { return w.cause }
+func (w *withStack) StackTrace() StackTrace        { return stackTrace(w.err) }
+func (w *withStack) Format(s fmt.State, verb rune) { w.err.(fmt.Formatter).Format(s, verb) }
+
+type withMessage struct {
+	err   error
+	cause error
+}
+
+func (w *withMessage) Error() string                 { return w.err.Error() }
+func (w *withMessage) Unwrap() error                 { return w.err }
+func (w *withMessage) Cause() error                  { return w.cause }
+func (w *withMessage) Format(s fmt.State, verb rune) { w.err.(fmt.Formatter).Format(s, verb) }
@@ -0,0 +1,99 @@
+package pkgerrors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+	compat "github.com/quenbyako/errors/pkgerrors"
+)
+
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+type causer interface {
+	Cause() error
+}
+
+func topFunc(t *testing.T, err error) string {
+	t.Helper()
+	st, ok := err.(stackTracer)
+	require.True(t, ok, "%T doesn't implement StackTrace()", err)
+	require.NotEmpty(t, st.StackTrace())
+	return fmt.Sprintf("%n", st.StackTrace()[0])
+}
+
+func TestNew(t *testing.T) {
+	err := compat.New("error")
+	require.EqualError(t, err, "error")
+	require.Equal(t, "TestNew", topFunc(t, err))
+	require.Equal(t, err, pkgerrors.Cause(err))
+	require.Regexp(t, "^error\ngithub.com/quenbyako/errors/pkgerrors_test.TestNew\n\t.+/pkgerrors_test.go:\\d+\n", fmt.Sprintf("%+v", err))
+
+	err = compat.Errorf("error %d", 42)
+	require.EqualError(t, err, "error 42")
+	require.Equal(t, "TestNew", topFunc(t, err))
+
+	// errors are also errors of github.com/quenbyako/errors
+	require.Equal(t, "github.com/quenbyako/errors/pkgerrors_test.TestNew", funcName(errors.Stack(err)[0]))
+}
+
+func TestWrap(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		msg  string
+	}{
+		{"Wrap", compat.Wrap(io.EOF, "read"), "read: EOF"},
+		{"Wrapf", compat.Wrapf(io.EOF, "read %d", 1), "read 1: EOF"},
+		{"WithStack", compat.WithStack(io.EOF), "EOF"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.EqualError(t, tt.err, tt.msg)
+			require.Equal(t, "TestWrap", topFunc(t, tt.err))
+			require.Equal(t, io.EOF, tt.err.(causer).Cause())
+			require.Equal(t, io.EOF, pkgerrors.Cause(tt.err))
+			require.Equal(t, io.EOF, compat.Cause(tt.err))
+			require.True(t, compat.Is(tt.err, io.EOF))
+		})
+	}
+
+	require.Nil(t, compat.Wrap(nil, "read"))
+	require.Nil(t, compat.Wrapf(nil, "read"))
+	require.Nil(t, compat.WithStack(nil))
+}
+
+func TestWithMessage(t *testing.T) {
+	err := compat.WithMessagef(compat.WithMessage(io.EOF, "read"), "load %s", "config")
+	require.EqualError(t, err, "load config: read: EOF")
+	require.Equal(t, io.EOF, pkgerrors.Cause(err))
+	_, ok := err.(stackTracer)
+	require.False(t, ok)
+
+	require.Nil(t, compat.WithMessage(nil, "read"))
+	require.Nil(t, compat.WithMessagef(nil, "read"))
+}
+
+func TestCauseChain(t *testing.T) {
+	root := compat.New("root")
+	err := compat.Wrap(compat.WithMessage(root, "middle"), "top")
+	require.Equal(t, root, pkgerrors.Cause(err))
+
+	var target *fmtError
+	require.True(t, compat.As(compat.Wrap(&fmtError{}, "wrapped"), &target))
+	require.NotNil(t, compat.Unwrap(err))
+}
+
+type fmtError struct{}
+
+func (*fmtError) Error() string { return "fmt error" }
+
+func funcName(f errors.Frame) string {
+	_, _, name := f.FuncInfo()
+	return name
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ANSI escape sequences, used by Pretty.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiDim   = "\x1b[2m"
+	ansiRed   = "\x1b[31m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// prettyColors is a color mode of Pretty: 0 means auto detection (colors
+// are enabled, unless NO_COLOR is set), 1 means enabled, 2 means disabled.
+var prettyColors int32
+
+// SetPrettyColors enables or disables colors in output of Pretty, overriding
+// detection by NO_COLOR environment variable (see https://no-color.org).
+func SetPrettyColors(enabled bool) {
+	var v int32 = 2
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&prettyColors, v)
+}
+
+func prettyColorsEnabled() bool {
+	switch atomic.LoadInt32(&prettyColors) {
+	case 1:
+		return true
+	case 2:
+		return false
+	default:
+		return os.Getenv("NO_COLOR") == ""
+	}
+}
+
+// Pretty renders err with its stack trace for humans, who read traces in
+// terminal, e.g. users of CLI tools:
+//
+//	read config: file not found
+//	    main.load        config.go:42
+//	    main.main        main.go:12
+//	    runtime.main     proc.go:271
+//
+// Function names are colorized, frames of standard library are dimmed,
+// columns are aligned and repeated frames (e.g. of recursive calls) are
+// collapsed. Colors are disabled, if NO_COLOR environment variable is set,
+// see also SetPrettyColors. Paths are rendered according to SetPathMode.
+// If err is nil, Pretty returns empty string.
+func Pretty(err error) string {
+	if err == nil {
+		return ""
+	}
+	color := prettyColorsEnabled()
+	paint := func(s, code string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	var b strings.Builder
+	b.WriteString(paint(err.Error(), ansiBold+ansiRed))
+	b.WriteString("\n")
+
+	stack := Stack(err)
+	if filter := globalFrameFilter(); filter != nil {
+		stack = stack.Filter(filter)
+	}
+	type row struct {
+		name, loc string
+		std       bool
+		repeated  int
+	}
+	var rows []row
+	width := 0
+	for _, f := range stack {
+		file, line, name := f.FuncInfo()
+		r := row{
+			name: name[strings.LastIndex(name, "/")+1:],
+			loc:  displayPath(f, file, name) + ":" + strconv.Itoa(line),
+			std:  isStdlibFunc(name),
+		}
+		if n := len(rows); n > 0 && rows[n-1].name == r.name && rows[n-1].loc == r.loc {
+			rows[n-1].repeated++
+			continue
+		}
+		if len(r.name) > width {
+			width = len(r.name)
+		}
+		rows = append(rows, r)
+	}
+
+	for _, r := range rows {
+		name := paint(r.name, ansiCyan)
+		line := name + strings.Repeat(" ", width-len(r.name)+2) + r.loc
+		if r.std {
+			line = paint(r.name+strings.Repeat(" ", width-len(r.name)+2)+r.loc, ansiDim)
+		}
+		b.WriteString("    " + line + "\n")
+		if r.repeated > 0 {
+			b.WriteString("    " + paint("... repeated "+strconv.Itoa(r.repeated)+" more times", ansiDim) + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// isStdlibFunc reports whether function belongs to standard library: first
+// element of its package path has no dot (unlike module paths).
+func isStdlibFunc(name string) bool {
+	pkg, _ := splitFuncName(name)
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+	if i := strings.Index(pkg, "/"); i >= 0 {
+		pkg = pkg[:i]
+	}
+	return !strings.Contains(pkg, ".")
+}
@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func prettyRecurse(n int) error {
+	if n == 0 {
+		return errors.New("too deep")
+	}
+	return prettyRecurse(n - 1)
+}
+
+func TestPretty(t *testing.T) {
+	errors.SetPrettyColors(false)
+	defer errors.SetPrettyColors(true)
+
+	require.Empty(t, errors.Pretty(nil))
+
+	lines := strings.Split(errors.Pretty(errors.Wrap(prettyRecurse(3), "solve")), "\n")
+	require.Equal(t, "solve: too deep", lines[0])
+	require.Regexp(t, `^    errors_test\.prettyRecurse +\S+pretty_test\.go:\d+$`, lines[1])
+	require.Regexp(t, `^    errors_test\.prettyRecurse +\S+pretty_test\.go:\d+$`, lines[2])
+	require.Equal(t, "    ... repeated 2 more times", lines[3])
+	require.Regexp(t, `^    errors_test\.TestPretty +\S+pretty_test\.go:\d+$`, lines[4])
+	require.Regexp(t, `^    testing\.tRunner +\S+testing\.go:\d+$`, lines[5])
+
+	// locations are aligned
+	col := regexp.MustCompile(`\s\S+:\d+$`)
+	want := col.FindStringIndex(lines[1])[0]
+	for _, line := range lines[1:] {
+		if loc := col.FindStringIndex(line); loc != nil && !strings.HasPrefix(line, "    ...") {
+			require.Equal(t, want, loc[0], line)
+		}
+	}
+}
+
+func TestPrettyColors(t *testing.T) {
+	errors.SetPrettyColors(true)
+	out := errors.Pretty(errors.New("boom"))
+	require.True(t, strings.HasPrefix(out, "\x1b[1m\x1b[31mboom\x1b[0m\n"), out)
+	require.Contains(t, out, "\x1b[36merrors_test.TestPrettyColors\x1b[0m")
+	require.Regexp(t, "\x1b\\[2mtesting\\.tRunner +\\S+\x1b\\[0m", out)
+
+	errors.SetPrettyColors(false)
+	defer errors.SetPrettyColors(true)
+	require.NotContains(t, errors.Pretty(errors.New("boom")), "\x1b[")
+}
@@ -0,0 +1,226 @@
+package errors
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+	"time"
+)
+
+// profiler aggregates call sites of created errors, see SetProfiling.
+var profiler struct {
+	sync.Mutex
+	remove  func()
+	start   time.Time
+	samples map[uint64]*profileSample
+}
+
+type profileSample struct {
+	stack StackTrace
+	count int64
+}
+
+// SetProfiling enables or disables collection of error creation sites: while
+// enabled, each error created with stack trace (see OnCreate) is counted by
+// its stack trace, so WriteProfile can export hot spots of errors. Enabling
+// profiling, which is already enabled, is no-op, disabling drops collected
+// samples.
+func SetProfiling(enabled bool) {
+	profiler.Lock()
+	defer profiler.Unlock()
+
+	switch {
+	case enabled && profiler.remove == nil:
+		profiler.start = time.Now()
+		profiler.samples = make(map[uint64]*profileSample)
+		profiler.remove = OnCreate(profileError)
+	case !enabled && profiler.remove != nil:
+		profiler.remove()
+		profiler.remove = nil
+		profiler.samples = nil
+	}
+}
+
+func profileError(err error, st StackTrace) {
+	if len(st) == 0 {
+		return
+	}
+
+	key := pcsHash(st)
+
+	profiler.Lock()
+	defer profiler.Unlock()
+	if profiler.samples == nil {
+		return
+	}
+	s, ok := profiler.samples[key]
+	if !ok {
+		s = &profileSample{stack: st}
+		profiler.samples[key] = s
+	}
+	s.count++
+}
+
+// pcsHash returns FNV-1a hash of raw program counters of st, without
+// resolving symbols.
+func pcsHash(st StackTrace) uint64 {
+	const prime = 1099511628211
+	h := uint64(14695981039346656037)
+	for _, f := range st {
+		pc := uintptr(f)
+		for i := 0; i < 8; i++ {
+			h ^= uint64(pc >> (8 * i) & 0xff)
+			h *= prime
+		}
+	}
+	return h
+}
+
+// WriteProfile writes gzipped profile in pprof format (profile.proto) to w,
+// where samples are counts of errors, created since profiling was enabled,
+// keyed by their stack traces. Profile can be explored with standard tools,
+// e.g. "go tool pprof -top errors.pprof". If profiling is disabled, profile
+// has no samples.
+func WriteProfile(w io.Writer) error {
+	profiler.Lock()
+	start := profiler.start
+	samples := make([]profileSample, 0, len(profiler.samples))
+	for _, s := range profiler.samples {
+		samples = append(samples, *s)
+	}
+	profiler.Unlock()
+
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(encodeProfile(samples, start, time.Now())); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// encodeProfile encodes samples as profile.proto message. Field numbers are
+// taken from github.com/google/pprof/proto/profile.proto.
+func encodeProfile(samples []profileSample, start, now time.Time) []byte {
+	var b protoBuffer
+
+	strs := map[string]int64{"": 0}
+	table := []string{""}
+	str := func(s string) int64 {
+		if i, ok := strs[s]; ok {
+			return i
+		}
+		strs[s] = int64(len(table))
+		table = append(table, s)
+		return strs[s]
+	}
+
+	valueType := func(field int, typ, unit string) {
+		var vt protoBuffer
+		vt.int(1, str(typ))
+		vt.int(2, str(unit))
+		b.bytes(field, vt)
+	}
+	valueType(1, "errors", "count") // sample_type
+
+	locations := map[Frame]uint64{}
+	functions := map[string]uint64{}
+	var locs, funcs protoBuffer
+
+	for _, s := range samples {
+		var sample protoBuffer
+		ids := make([]uint64, 0, len(s.stack))
+		for _, f := range s.stack {
+			id, ok := locations[f]
+			if !ok {
+				file, line, name := f.FuncInfo()
+				fnID, ok := functions[name]
+				if !ok {
+					fnID = uint64(len(functions) + 1)
+					functions[name] = fnID
+
+					var fn protoBuffer
+					fn.uint(1, fnID)
+					fn.int(2, str(name))
+					fn.int(3, str(name))
+					fn.int(4, str(file))
+					funcs.bytes(5, fn)
+				}
+
+				id = uint64(len(locations) + 1)
+				locations[f] = id
+
+				var ln, loc protoBuffer
+				ln.uint(1, fnID)
+				ln.int(2, int64(line))
+				loc.uint(1, id)
+				if !f.isSynthetic() {
+					loc.uint(3, uint64(f.pc()))
+				}
+				loc.bytes(4, ln)
+				locs.bytes(4, loc)
+			}
+			ids = append(ids, id)
+		}
+		sample.packed(1, ids)
+		sample.packed(2, []uint64{uint64(s.count)})
+		b.bytes(2, sample)
+	}
+	b = append(b, locs...)
+	b = append(b, funcs...)
+
+	timeNanos := now.UnixNano()
+	var duration int64
+	if !start.IsZero() {
+		duration = now.Sub(start).Nanoseconds()
+	}
+	valueType(11, "errors", "count") // period_type
+	b.int(12, 1)                     // period
+	b.int(9, timeNanos)
+	b.int(10, duration)
+
+	for _, s := range table {
+		b.string(6, s)
+	}
+
+	return b
+}
+
+// protoBuffer is a minimal protobuf wire format encoder.
+type protoBuffer []byte
+
+func (b *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		*b = append(*b, byte(v)|0x80)
+		v >>= 7
+	}
+	*b = append(*b, byte(v))
+}
+
+func (b *protoBuffer) tag(field, wireType int) { b.varint(uint64(field)<<3 | uint64(wireType)) }
+
+func (b *protoBuffer) uint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	b.tag(field, 0)
+	b.varint(v)
+}
+
+func (b *protoBuffer) int(field int, v int64) { b.uint(field, uint64(v)) }
+
+func (b *protoBuffer) bytes(field int, v []byte) {
+	b.tag(field, 2)
+	b.varint(uint64(len(v)))
+	*b = append(*b, v...)
+}
+
+// string always encodes s, even if it's empty, since string table must start
+// with empty string.
+func (b *protoBuffer) string(field int, s string) { b.bytes(field, []byte(s)) }
+
+func (b *protoBuffer) packed(field int, v []uint64) {
+	var p protoBuffer
+	for _, x := range v {
+		p.varint(x)
+	}
+	b.bytes(field, p)
+}
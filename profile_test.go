@@ -0,0 +1,62 @@
+package errors_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func profiledError(i int) error {
+	if i%2 == 0 {
+		return errors.New("even")
+	}
+	return errors.New("odd")
+}
+
+func TestWriteProfile(t *testing.T) {
+	errors.SetProfiling(true)
+	defer errors.SetProfiling(false)
+
+	for i := 0; i < 10; i++ {
+		err := profiledError(i)
+		if i < 3 {
+			// wrapping doesn't record new stack, so it's not counted
+			_ = errors.Wrap(err, "wrapped")
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, errors.WriteProfile(&buf))
+
+	p, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.NoError(t, p.CheckValid())
+	require.Equal(t, "errors", p.SampleType[0].Type)
+	require.Equal(t, "count", p.SampleType[0].Unit)
+
+	counts := map[int64]int64{}
+	for _, s := range p.Sample {
+		require.Equal(t, errors.PkgName+".profiledError", s.Location[0].Line[0].Function.Name)
+		require.Equal(t, errors.PkgName+".TestWriteProfile", s.Location[1].Line[0].Function.Name)
+		counts[s.Location[0].Line[0].Line] += s.Value[0]
+	}
+	require.Len(t, counts, 2)
+	for _, count := range counts {
+		require.EqualValues(t, 5, count)
+	}
+}
+
+func TestWriteProfileDisabled(t *testing.T) {
+	_ = errors.New("not counted")
+
+	var buf bytes.Buffer
+	require.NoError(t, errors.WriteProfile(&buf))
+
+	p, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.Empty(t, p.Sample)
+}
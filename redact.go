@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RedactedPlaceholder replaces secrets in messages of errors, returned by
+// Redact.
+const RedactedPlaceholder = "[REDACTED]"
+
+type withSafeMessage struct {
+	cause error
+	msg   string
+}
+
+// WithSafeMessage annotates err with a message, which is safe to show to
+// clients (see Public). Message of err stays untouched, so logs keep full
+// details.
+// If err is nil, WithSafeMessage returns nil.
+func WithSafeMessage(err error, publicMsg string) error {
+	if err == nil {
+		return nil
+	}
+	return &withSafeMessage{cause: err, msg: publicMsg}
+}
+
+func (w *withSafeMessage) Error() string   { return w.cause.Error() }
+func (w *withSafeMessage) Unwrap() error   { return w.cause }
+func (w *withSafeMessage) message() string { return "" }
+
+func (w *withSafeMessage) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withSafeMessage) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Public returns message of err, which is safe to show to clients: the
+// outermost message in err's chain, attached by WithSafeMessage, or message
+// of sanitized error (see Sanitize). Otherwise, Public returns
+// DefaultPublicMessage, so internal messages (SQL queries, file paths, etc.)
+// never leak.
+// If err is nil, Public returns empty string.
+func Public(err error) string {
+	if err == nil {
+		return ""
+	}
+	for ; err != nil; err = Unwrap(err) {
+		switch e := err.(type) {
+		case *withSafeMessage:
+			return e.msg
+		case *sanitized:
+			return e.msg
+		}
+	}
+	return DefaultPublicMessage
+}
+
+// redacted hides secrets in messages of its cause.
+type redacted struct {
+	cause    error
+	replacer *strings.Replacer
+}
+
+// Redact returns an error, which message and extended format (%+v) have all
+// occurrences of secrets replaced by RedactedPlaceholder. Returned error
+// keeps stack trace of err, and Is and As still match errors of its chain,
+// but Unwrap doesn't expose them, so secrets can't leak through messages of
+// causes.
+// If err is nil, Redact returns nil.
+func Redact(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, s := range secrets {
+		if s != "" {
+			pairs = append(pairs, s, RedactedPlaceholder)
+		}
+	}
+	return &redacted{cause: err, replacer: strings.NewReplacer(pairs...)}
+}
+
+func (r *redacted) Error() string          { return r.replacer.Replace(r.cause.Error()) }
+func (r *redacted) message() string        { return r.Error() }
+func (r *redacted) stackTrace() StackTrace { return Stack(r.cause) }
+
+func (r *redacted) Is(target error) bool       { return Is(r.cause, target) }
+func (r *redacted) As(target interface{}) bool { return As(r.cause, target) }
+
+func (r *redacted) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			var buf bytes.Buffer
+			formatDetailedVerb(&buf, r.cause, detailVerb(s))
+			io.WriteString(s, r.replacer.Replace(buf.String()))
+			formatFooter(s, r.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", r.Error())
+	}
+}
+
+func (r *redacted) MarshalJSON() ([]byte, error) { return ToJSON(r) }
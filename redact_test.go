@@ -0,0 +1,57 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestPublic(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, ""},
+		{"internal", errors.New("pq: relation \"users\" does not exist"), errors.DefaultPublicMessage},
+		{"safe", errors.Wrap(errors.WithSafeMessage(io.EOF, "user not found"), "query"), "user not found"},
+		{"outermost", errors.WithSafeMessage(errors.WithSafeMessage(io.EOF, "inner"), "outer"), "outer"},
+		{"sanitized", errors.Sanitize(io.EOF, errors.SanitizePolicy{PublicMessage: "bad request"}), "bad request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Public(tt.err))
+		})
+	}
+}
+
+func TestWithSafeMessage(t *testing.T) {
+	require.Nil(t, errors.WithSafeMessage(nil, "safe"))
+
+	err := errors.WithSafeMessage(errors.Wrap(io.EOF, "query"), "user not found")
+	require.EqualError(t, err, "query: EOF")
+	require.True(t, errors.Is(err, io.EOF))
+	require.EqualError(t, errors.Sanitize(err, errors.SanitizePolicy{}), "user not found")
+}
+
+func TestRedact(t *testing.T) {
+	require.Nil(t, errors.Redact(nil, "secret"))
+
+	origin := errors.Wrap(io.EOF, `connect to "postgres://admin:hunter2@db/app"`)
+	err := errors.Redact(errors.Wrap(origin, "init"), "hunter2", "")
+
+	require.EqualError(t, err, `init: connect to "postgres://admin:[REDACTED]@db/app": EOF`)
+	require.True(t, errors.Is(err, io.EOF))
+	require.Nil(t, errors.Unwrap(err))
+	require.Equal(t, errors.Stack(origin), errors.Stack(err))
+
+	detailed := fmt.Sprintf("%+v", err)
+	require.Contains(t, detailed, "[REDACTED]")
+	require.NotContains(t, detailed, "hunter2")
+	require.Contains(t, detailed, errors.PkgName+".TestRedact")
+}
@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// RemapStep describes a single remapping, recorded by Remapper in debug mode
+// (see Remapper.Debug).
+type RemapStep struct {
+	// Rule is a name of function, which created matched rule, e.g.
+	// "github.com/quenbyako/errors.IsRemapperFunc.func1".
+	Rule string
+	// Index is an index of matched rule in the rule set.
+	Index int
+	// Input is an error, passed to Remap.
+	Input error
+	// Output is an error, returned by matched rule.
+	Output error
+}
+
+// String returns description of step in the form of
+// "rule #1 (pkg.Rule): input -> output".
+func (s RemapStep) String() string {
+	return fmt.Sprintf("rule #%d (%s): %v -> %v", s.Index, s.Rule, s.Input, s.Output)
+}
+
+// remapTraced is an error, remapped by Remapper in debug mode.
+type remapTraced struct {
+	cause error
+	step  RemapStep
+}
+
+func (r *remapTraced) Error() string   { return r.cause.Error() }
+func (r *remapTraced) Unwrap() error   { return r.cause }
+func (r *remapTraced) message() string { return "" }
+
+func (r *remapTraced) Format(s fmt.State, verb rune) { formatTransparent(s, verb, r.cause) }
+
+func (r *remapTraced) MarshalJSON() ([]byte, error) { return ToJSON(r) }
+
+// RemapTrace returns remapping steps, recorded in err's chain by remappers
+// in debug mode (see Remapper.Debug), from the last remapping to the first
+// one. If err wasn't remapped in debug mode, RemapTrace returns nil.
+func RemapTrace(err error) []RemapStep {
+	var res []RemapStep
+	walkTree(err, func(e error) {
+		if r, ok := e.(*remapTraced); ok {
+			res = append(res, r.step)
+		}
+	})
+	return res
+}
+
+// ruleName returns name of function, which implements rule.
+func ruleName(rule ErrRemapperFunc) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(rule).Pointer())
+	if fn == nil {
+		return unknown
+	}
+	return fn.Name()
+}
@@ -0,0 +1,49 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestRemapTrace(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errMissing := errors.New("missing")
+
+	input := errors.Wrap(io.EOF, "read")
+	r := errors.NewRemapper(
+		errors.ValueRemapper(io.ErrUnexpectedEOF, errMissing),
+		errors.ValueRemapper(io.EOF, errNotFound),
+	).Debug()
+
+	err := r.Remap(input)
+	require.Equal(t, "not found", err.Error())
+	require.ErrorIs(t, err, errNotFound)
+
+	steps := errors.RemapTrace(err)
+	require.Len(t, steps, 1)
+	require.Equal(t, 1, steps[0].Index)
+	require.Equal(t, errors.PkgNameRaw+".ValueRemapperFunc.func1", steps[0].Rule)
+	require.Equal(t, input, steps[0].Input)
+	require.Equal(t, errNotFound, steps[0].Output)
+	require.Equal(t, "rule #1 ("+errors.PkgNameRaw+".ValueRemapperFunc.func1): read: EOF -> not found", steps[0].String())
+
+	require.Nil(t, errors.RemapTrace(errors.NewRemapper(errors.IsRemapper(io.EOF, errNotFound)).Remap(io.EOF)))
+	require.Equal(t, io.ErrClosedPipe, r.Remap(io.ErrClosedPipe))
+}
+
+func TestRemapTraceKeepOriginal(t *testing.T) {
+	first := errors.NewRemapper(errors.IsRemapper(io.EOF, io.ErrUnexpectedEOF)).Debug()
+	second := errors.NewRemapper(errors.IsRemapper(io.ErrUnexpectedEOF, io.ErrClosedPipe)).KeepOriginal().Debug()
+
+	err := second.Remap(first.Remap(io.EOF))
+	require.Equal(t, io.ErrClosedPipe.Error(), err.Error())
+
+	steps := errors.RemapTrace(err)
+	require.Len(t, steps, 2)
+	require.Equal(t, io.ErrClosedPipe, steps[0].Output)
+	require.Equal(t, io.ErrUnexpectedEOF, steps[1].Output)
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+// remote is an error reconstructed from serialized representation of another
+// error, possibly created by another service.
+type remote struct {
+	msg    string
+	stack  StackTrace
+	at     Frame
+	params []Field
+	cause  error
+}
+
+func (r *remote) Error() string          { return r.msg }
+func (r *remote) Unwrap() error          { return r.cause }
+func (r *remote) stackTrace() StackTrace { return r.stack }
+func (r *remote) fields() []Field        { return r.params }
+func (r *remote) message() string        { return trimCause(r.msg, r.cause) }
+func (r *remote) wrappedAt() Frame       { return r.at }
+
+func (r *remote) Format(s fmt.State, verb rune) { formatRemote(s, verb, r, r.cause) }
+
+// remoteMulti is a reconstructed multi-error.
+type remoteMulti struct {
+	remote
+	causes []error
+}
+
+func (r *remoteMulti) Unwrap() []error { return r.causes }
+func (r *remoteMulti) message() string { return r.msg }
+
+func (r *remoteMulti) Format(s fmt.State, verb rune) { formatRemote(s, verb, &r.remote, nil) }
+
+func (r *remoteMulti) MarshalJSON() ([]byte, error) { return ToJSON(r) }
+func (r *remote) MarshalJSON() ([]byte, error)      { return ToJSON(r) }
+
+func formatRemote(s fmt.State, verb rune, r *remote, cause error) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			switch msg := trimCause(r.msg, cause); {
+			case cause == nil:
+				io.WriteString(s, r.msg)
+			case msg == "":
+				formatDetailed(s, cause)
+			default:
+				formatMessage(s, msg, cause, r.at)
+			}
+			if len(r.stack) > 0 {
+				io.WriteString(s, "\n")
+				r.stack.Format(s, verb)
+			}
+			if cause != nil {
+				formatFooter(s, cause)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, r.msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", r.msg)
+	}
+}
@@ -0,0 +1,234 @@
+package errors
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+type sourceURLHolder struct{ template string }
+
+var sourceURL atomic.Value // sourceURLHolder
+
+// SetSourceURL sets template of links to source code, which are rendered by
+// RenderHTML and RenderMarkdown for stack frames. Template may contain
+// placeholders {file} (full path of source file), {path} (path relative to
+// module root, see PathTrim) and {line}:
+//
+//	errors.SetSourceURL("https://github.com/org/repo/blob/main/{path}#L{line}")
+//
+// Empty template (default) disables links.
+func SetSourceURL(template string) { sourceURL.Store(sourceURLHolder{template: template}) }
+
+// frameURL returns link to source of the frame, or empty string, if links
+// are disabled.
+func frameURL(f Frame) string {
+	h, _ := sourceURL.Load().(sourceURLHolder)
+	if h.template == "" {
+		return ""
+	}
+	file, line, name := f.FuncInfo()
+	if file == unknown {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{file}", file,
+		"{path}", trimPath(f, file, name),
+		"{line}", strconv.Itoa(line),
+	).Replace(h.template)
+}
+
+// renderFrame is a frame, prepared for rendering.
+type renderFrame struct {
+	name, loc, url string
+}
+
+// renderSection is a single error of the chain, prepared for rendering.
+type renderSection struct {
+	msg    string
+	fields []Field
+	frames []renderFrame
+	// branches are chains of errors, aggregated by multi-error.
+	branches [][]renderSection
+}
+
+// renderSections splits err's chain into sections: one per error with own
+// message, stack trace or branches. Transparent wrappers are merged into the
+// next section.
+func renderSections(err error) []renderSection {
+	var res []renderSection
+	var cur renderSection
+	for err != nil {
+		if f, ok := err.(interface{ fields() []Field }); ok {
+			cur.fields = append(cur.fields, f.fields()...)
+		}
+		if w, ok := err.(interface{ wrappedAt() Frame }); ok && w.wrappedAt() != 0 {
+			cur.frames = append(cur.frames, newRenderFrame(w.wrappedAt()))
+		}
+		if stack, ok := stackOf(err); ok {
+			for _, f := range stack {
+				cur.frames = append(cur.frames, newRenderFrame(f))
+			}
+		}
+		if msg := ownMessage(err); msg != "" {
+			cur.msg = msg
+		}
+
+		switch e := err.(type) {
+		case *truncated:
+			// causes repeat messages, which were truncated
+			err = nil
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				cur.branches = append(cur.branches, renderSections(child))
+			}
+			err = nil
+		default:
+			err = nil
+		}
+		if cur.msg != "" || cur.branches != nil || err == nil {
+			res = append(res, cur)
+			cur = renderSection{}
+		}
+	}
+	return res
+}
+
+func newRenderFrame(f Frame) renderFrame {
+	file, line, name := f.FuncInfo()
+	return renderFrame{
+		name: name,
+		loc:  displayPath(f, file, name) + ":" + strconv.Itoa(line),
+		url:  frameURL(f),
+	}
+}
+
+// RenderHTML renders err's chain as HTML fragment for error dashboards. Each
+// error of the chain is a collapsible <details> section with its message,
+// fields and stack frames, causes are nested into sections of their
+// wrappers. Summary of the outermost section is the full message of err.
+// Frames are linked to source code, if SetSourceURL is configured.
+// If err is nil, RenderHTML returns empty string.
+func RenderHTML(err error) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	sections := renderSections(err)
+	sections[0].msg = err.Error()
+	b.WriteString(`<div class="error">` + "\n")
+	renderHTMLSections(&b, sections, true)
+	b.WriteString("</div>\n")
+	return b.String()
+}
+
+func renderHTMLSections(b *strings.Builder, sections []renderSection, open bool) {
+	if len(sections) == 0 {
+		return
+	}
+	s := sections[0]
+	if open {
+		b.WriteString("<details open>")
+	} else {
+		b.WriteString("<details>")
+	}
+	b.WriteString("<summary>" + html.EscapeString(s.msg) + "</summary>\n")
+	if len(s.fields) > 0 {
+		b.WriteString(`<p class="fields">` + html.EscapeString(formatFields(s.fields)) + "</p>\n")
+	}
+	if len(s.frames) > 0 {
+		b.WriteString(`<ul class="stack">` + "\n")
+		for _, f := range s.frames {
+			loc := html.EscapeString(f.loc)
+			if f.url != "" {
+				loc = `<a href="` + html.EscapeString(f.url) + `">` + loc + "</a>"
+			}
+			b.WriteString("<li><code>" + html.EscapeString(f.name) + "</code> " + loc + "</li>\n")
+		}
+		b.WriteString("</ul>\n")
+	}
+	for _, branch := range s.branches {
+		renderHTMLSections(b, branch, false)
+	}
+	renderHTMLSections(b, sections[1:], false)
+	b.WriteString("</details>\n")
+}
+
+// RenderMarkdown renders err's chain as Markdown for incident comments,
+// posted by bots. Message of err is rendered in bold, causes are rendered as
+// collapsible <details> sections (supported by GitHub, GitLab and others).
+// Frames are linked to source code, if SetSourceURL is configured.
+// If err is nil, RenderMarkdown returns empty string.
+func RenderMarkdown(err error) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	sections := renderSections(err)
+	b.WriteString("**" + markdownEscape(err.Error()) + "**\n\n")
+	renderMarkdownBody(&b, sections[0])
+	renderMarkdownSections(&b, sections[1:])
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func renderMarkdownSections(b *strings.Builder, sections []renderSection) {
+	for _, s := range sections {
+		b.WriteString("<details><summary>caused by: " + html.EscapeString(s.msg) + "</summary>\n\n")
+		renderMarkdownBody(b, s)
+		b.WriteString("</details>\n\n")
+	}
+}
+
+func renderMarkdownBody(b *strings.Builder, s renderSection) {
+	if len(s.fields) > 0 {
+		b.WriteString("Fields: " + markdownCode(formatFields(s.fields)) + "\n\n")
+	}
+	for _, f := range s.frames {
+		loc := markdownEscape(f.loc)
+		if f.url != "" {
+			loc = "[" + loc + "](" + markdownURL(f.url) + ")"
+		}
+		b.WriteString("- " + markdownCode(f.name) + " " + loc + "\n")
+	}
+	if len(s.frames) > 0 {
+		b.WriteString("\n")
+	}
+	for _, branch := range s.branches {
+		renderMarkdownSections(b, branch)
+	}
+}
+
+// markdownEscape escapes characters, which have special meaning in inline
+// Markdown.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`",
+		"[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;", "\n", " ",
+	).Replace(s)
+}
+
+// markdownCode renders s as inline code. Fence is longer than any run of
+// backticks in s, so s can't close the code span.
+func markdownCode(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// markdownURL escapes characters, which can end link destination in
+// Markdown.
+func markdownURL(s string) string {
+	return strings.NewReplacer(
+		" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E",
+		"\n", "%0A", `\`, "%5C",
+	).Replace(s)
+}
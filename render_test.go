@@ -0,0 +1,70 @@
+package errors_test
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestRenderHTML(t *testing.T) {
+	require.Empty(t, errors.RenderHTML(nil))
+
+	errors.SetSourceURL("https://code.example.com/{path}#L{line}")
+	defer errors.SetSourceURL("")
+
+	err := errors.Wrap(errors.WithField(errors.New("<not found>"), "id", 42), "load")
+	out := errors.RenderHTML(err)
+
+	require.True(t, strings.HasPrefix(out, "<div class=\"error\">\n<details open><summary>load: &lt;not found&gt;</summary>\n"), out)
+	require.Contains(t, out, "<details><summary>&lt;not found&gt;</summary>\n<p class=\"fields\">id=42</p>\n")
+	require.Regexp(t, regexp.MustCompile(`<li><code>`+regexp.QuoteMeta(errors.PkgName)+`\.TestRenderHTML</code> <a href="https://code\.example\.com/\S*render_test\.go#L\d+">\S*render_test\.go:\d+</a></li>`), out)
+	require.Equal(t, 2, strings.Count(out, "<details"))
+	require.Equal(t, 2, strings.Count(out, "</details>"))
+}
+
+func TestRenderHTMLJoined(t *testing.T) {
+	out := errors.RenderHTML(errors.Join(io.EOF, io.ErrUnexpectedEOF))
+	require.Contains(t, out, "<details><summary>EOF</summary>\n</details>\n")
+	require.Contains(t, out, "<details><summary>unexpected EOF</summary>\n</details>\n")
+	require.NotContains(t, out, "<a href")
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	require.Empty(t, errors.RenderMarkdown(nil))
+
+	errors.SetSourceURL("https://code.example.com/{path}#L{line}")
+	defer errors.SetSourceURL("")
+
+	out := errors.RenderMarkdown(errors.Wrap(errors.New("file_not_found"), "read config"))
+	lines := strings.Split(out, "\n")
+	require.Equal(t, "**read config: file\\_not\\_found**", lines[0])
+	require.Equal(t, "", lines[1])
+	require.Regexp(t, "^- `"+regexp.QuoteMeta(errors.PkgName)+`\.TestRenderMarkdown`+"` "+`\[\S*render\\_test\.go:\d+\]\(https://code\.example\.com/\S*render_test\.go#L\d+\)$`, lines[2])
+	require.Contains(t, out, "<details><summary>caused by: file_not_found</summary>\n\n- `")
+	require.True(t, strings.HasSuffix(out, "</details>"), out)
+}
+
+func TestRenderMarkdownEscaping(t *testing.T) {
+	errors.SetSourceURL("https://code.example.com/{path}#L{line}")
+	defer errors.SetSourceURL("")
+
+	err, jsonErr := errors.FromJSON([]byte(`{
+		"message": "boom",
+		"fields": {"note": "` + "`[x](http://evil)`" + `"},
+		"stack": ["main.F` + "`x`" + ` /src/[x](http://evil).go:1"]
+	}`))
+	require.NoError(t, jsonErr)
+
+	lines := strings.Split(errors.RenderMarkdown(err), "\n")
+	require.Equal(t, "Fields: `` note=`[x](http://evil)` ``", lines[2])
+	require.Equal(t, "- `` main.F`x` `` [/src/\\[x\\](http://evil).go:1](https://code.example.com//src/[x]%28http://evil%29.go#L1)", lines[4])
+
+	errors.SetSourceURL("")
+	lines = strings.Split(errors.RenderMarkdown(err), "\n")
+	require.Equal(t, "- `` main.F`x` `` /src/\\[x\\](http://evil).go:1", lines[4])
+}
@@ -0,0 +1,176 @@
+package errors
+
+import "strings"
+
+// ReplaceCause returns a copy of err's chain with root cause (see Cause)
+// replaced by newCause. Wrappers of this package are rebuilt as is, so
+// messages, stack traces, fields and other annotations added on the way up
+// are preserved. Foreign wrappers can't be rebuilt, so they are replaced by
+// wrappers with the same own message and stack trace. It's useful to
+// sanitize driver internals out of errors before crossing a trust boundary
+// while keeping context, added by callers:
+//
+//	err = errors.ReplaceCause(err, errors.NoStack("database failure"))
+//
+// If err is nil, ReplaceCause returns newCause. If newCause is nil,
+// ReplaceCause returns nil.
+func ReplaceCause(err, newCause error) error {
+	if err == nil || newCause == nil {
+		return newCause
+	}
+	chain, _ := splitChain(err)
+	return rebuildChain(chain, newCause)
+}
+
+// Strip returns a copy of err's chain without wrappers, for which match
+// returns true. Root cause (see Cause) is never removed, other errors of the
+// chain are rebuilt the same way, as ReplaceCause does. If no wrapper
+// matched, err is returned as is.
+//
+//	err = errors.Strip(err, func(e error) bool {
+//		_, ok := e.(*pgconn.PgError)
+//		return ok
+//	})
+func Strip(err error, match func(error) bool) error {
+	chain, root := splitChain(err)
+	kept := chain[:0:0]
+	for _, e := range chain {
+		if !match(e) {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == len(chain) {
+		return err
+	}
+	return rebuildChain(kept, root)
+}
+
+// splitChain returns wrappers of err's chain from outermost to innermost
+// and its root cause.
+func splitChain(err error) (chain []error, root error) {
+	root = Cause(err)
+	for e := err; e != root; e = Unwrap(e) {
+		chain = append(chain, e)
+	}
+	return chain, root
+}
+
+// rebuildChain wraps cause into copies of wrappers of the chain, starting
+// with the innermost one.
+func rebuildChain(chain []error, cause error) error {
+	for i := len(chain) - 1; i >= 0; i-- {
+		cause = rewrap(chain[i], cause)
+	}
+	return cause
+}
+
+// rewrap returns copy of wrapper w with cause replaced.
+func rewrap(w, cause error) error {
+	switch e := w.(type) {
+	case *withStack:
+		c := *e
+		c.error = cause
+		return &c
+	case *adopted:
+		return &adopted{cause}
+	case *withMessage:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withField:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withHint:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withParams:
+		c := *e
+		c.base = cause
+		return &c
+	case *withCode:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withKind:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withBuildInfo:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withTags:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withSeverity:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withHTTPStatus:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withExitCode:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withRetry:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withTime:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withLabels:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withMessageKey:
+		c := *e
+		c.cause = cause
+		return &c
+	case *withSafeMessage:
+		c := *e
+		c.cause = cause
+		return &c
+	case *remapTraced:
+		c := *e
+		c.cause = cause
+		return &c
+	case *remapped:
+		c := *e
+		c.from = cause
+		return &c
+	case *remote:
+		c := *e
+		c.cause = cause
+		c.msg = cause.Error()
+		if msg := e.message(); msg != "" {
+			c.msg = joinMessage(msg, cause)
+		}
+		return &c
+	case *wrapError:
+		c := *e
+		c.cause = cause
+		msg := e.cause.Error()
+		if i := strings.LastIndex(e.msg, msg); i >= 0 {
+			c.msg = e.msg[:i] + cause.Error() + e.msg[i+len(msg):]
+		}
+		return &c
+	case *truncated:
+		return Truncate(cause, e.limits)
+	}
+
+	// foreign wrapper: keep its own message and stack trace
+	if msg := ownMessage(w); msg != "" {
+		cause = &withMessage{cause: cause, msg: msg}
+	}
+	if stack, ok := stackOf(w); ok && len(stack) > 0 {
+		cause = &withStack{cause, stack}
+	}
+	return cause
+}
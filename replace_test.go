@@ -0,0 +1,63 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type driverErr struct{ cause error }
+
+func (e *driverErr) Error() string { return "pq: relation users: " + e.cause.Error() }
+func (e *driverErr) Unwrap() error { return e.cause }
+
+func TestReplaceCause(t *testing.T) {
+	sanitized := errors.NoStack("database failure")
+	require.Nil(t, errors.ReplaceCause(io.EOF, nil))
+	require.Equal(t, sanitized, errors.ReplaceCause(nil, sanitized))
+
+	inner := errors.WithKind(errors.Wrap(io.EOF, "query users"), errors.KindUnavailable)
+	err := errors.WithField(errors.Wrap(inner, "list users"), "page", 2)
+
+	got := errors.ReplaceCause(err, sanitized)
+	require.Equal(t, "list users: query users: database failure", got.Error())
+	require.Equal(t, sanitized, errors.Cause(got))
+	require.NotErrorIs(t, got, io.EOF)
+	require.Equal(t, errors.KindUnavailable, errors.KindOf(got))
+	require.Equal(t, map[string]interface{}{"page": 2}, errors.Fields(got))
+	require.Equal(t, errors.Stack(err), errors.Stack(got))
+
+	// original error is not modified
+	require.Equal(t, "list users: query users: EOF", err.Error())
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReplaceCauseForeign(t *testing.T) {
+	err := errors.Wrap(&driverErr{io.EOF}, "load")
+	got := errors.ReplaceCause(err, io.ErrUnexpectedEOF)
+	require.Equal(t, "load: pq: relation users: unexpected EOF", got.Error())
+	require.Equal(t, io.ErrUnexpectedEOF, errors.Cause(got))
+}
+
+func TestStrip(t *testing.T) {
+	err := errors.Wrap(&driverErr{errors.Wrap(io.EOF, "read")}, "load")
+
+	got := errors.Strip(err, func(e error) bool {
+		_, ok := e.(*driverErr)
+		return ok
+	})
+	require.Equal(t, "load: read: EOF", got.Error())
+	require.ErrorIs(t, got, io.EOF)
+	require.Equal(t, errors.Stack(err), errors.Stack(got))
+	require.Equal(t, "load: read: EOF", fmt.Sprintf("%v", got))
+
+	same := errors.Strip(err, func(error) bool { return false })
+	require.Equal(t, err, same)
+
+	root := errors.Strip(io.EOF, func(error) bool { return true })
+	require.Equal(t, io.EOF, root, "root cause is never removed")
+}
@@ -0,0 +1,187 @@
+// Package report exports errors of github.com/quenbyako/errors package into
+// error reporting services. It contains a built-in builder of events in
+// Sentry format, which converts error chain and stack traces into Sentry
+// exceptions and stack frames, so errors can be reported with single Capture
+// call without manual frame translation.
+package report
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/quenbyako/errors"
+)
+
+// Exporter sends events to error reporting service.
+type Exporter interface {
+	Export(event *Event) error
+}
+
+// ExporterFunc is an adapter to allow the use of ordinary functions as
+// Exporter, e.g. to forward events into sentry-go hub.
+type ExporterFunc func(event *Event) error
+
+// Export calls f(event).
+func (f ExporterFunc) Export(event *Event) error { return f(event) }
+
+// Capture builds an event from err with DefaultBuilder and exports it. If err
+// is nil, Capture does nothing.
+func Capture(exp Exporter, err error) error {
+	if err == nil {
+		return nil
+	}
+	return exp.Export(DefaultBuilder.Build(err))
+}
+
+// Event is an error event in Sentry format.
+type Event struct {
+	EventID   string                 `json:"event_id"`
+	Timestamp time.Time              `json:"timestamp"`
+	Level     string                 `json:"level"`
+	Platform  string                 `json:"platform"`
+	Message   string                 `json:"message,omitempty"`
+	Exception []Exception            `json:"exception,omitempty"`
+	Extra     map[string]interface{} `json:"extra,omitempty"`
+	Tags      map[string]string      `json:"tags,omitempty"`
+}
+
+// Exception is a single error of the chain in Sentry format.
+type Exception struct {
+	Type       string      `json:"type,omitempty"`
+	Value      string      `json:"value,omitempty"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace is a stack trace in Sentry format. Frames are ordered from the
+// oldest call to the newest one.
+type Stacktrace struct {
+	Frames []Frame `json:"frames"`
+}
+
+// Frame is a single stack frame in Sentry format.
+type Frame struct {
+	Function string `json:"function,omitempty"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Builder builds events from errors.
+type Builder struct {
+	// InAppPrefixes are prefixes of package paths, which are considered as
+	// application code. If empty, path of main module is used.
+	InAppPrefixes []string
+}
+
+// DefaultBuilder is a builder used by Capture.
+var DefaultBuilder = &Builder{}
+
+// Build converts err into an event. Every error in err's chain, which has
+// its own stack trace, becomes separate exception, ordered from the
+// innermost to the outermost one, as Sentry expects. Fields of registered
+// enrichers (see errors.AddEnricher) become tags of the event. If err is
+// nil, Build returns nil.
+func (b *Builder) Build(err error) *Event {
+	if err == nil {
+		return nil
+	}
+	event := &Event{
+		EventID:   newEventID(),
+		Timestamp: time.Now().UTC(),
+		Level:     "error",
+		Platform:  "go",
+		Message:   err.Error(),
+	}
+	if fields := errors.Fields(err); len(fields) > 0 {
+		event.Extra = fields
+	}
+	for _, field := range errors.Enrichment() {
+		if event.Tags == nil {
+			event.Tags = make(map[string]string)
+		}
+		event.Tags[field.Key] = fmt.Sprint(field.Value)
+	}
+
+	prefixes := b.inAppPrefixes()
+	for _, stack := range errors.Stacks(err) {
+		event.Exception = append(event.Exception, Exception{
+			Type:       "error",
+			Stacktrace: b.stacktrace(stack, prefixes),
+		})
+	}
+	if len(event.Exception) == 0 {
+		event.Exception = append(event.Exception, Exception{Type: "error"})
+	}
+	// outermost exception is the last one and carries the whole message
+	for i, j := 0, len(event.Exception)-1; i < j; i, j = i+1, j-1 {
+		event.Exception[i], event.Exception[j] = event.Exception[j], event.Exception[i]
+	}
+	event.Exception[len(event.Exception)-1].Value = err.Error()
+	event.Exception[len(event.Exception)-1].Type = typeName(errors.Cause(err))
+
+	return event
+}
+
+func (b *Builder) stacktrace(stack errors.StackTrace, prefixes []string) *Stacktrace {
+	st := &Stacktrace{Frames: make([]Frame, 0, len(stack))}
+	for i := len(stack) - 1; i >= 0; i-- {
+		file, line, name := stack[i].FuncInfo()
+		module, function := splitFuncName(name)
+		st.Frames = append(st.Frames, Frame{
+			Function: function,
+			Module:   module,
+			Filename: path.Base(file),
+			AbsPath:  file,
+			Lineno:   line,
+			InApp:    isInApp(module, prefixes),
+		})
+	}
+	return st
+}
+
+func (b *Builder) inAppPrefixes() []string {
+	if len(b.InAppPrefixes) > 0 {
+		return b.InAppPrefixes
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		return []string{info.Main.Path}
+	}
+	return nil
+}
+
+func isInApp(module string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if module == prefix || strings.HasPrefix(module, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitFuncName splits full function name into package path and function
+// name, e.g. "github.com/a/b.(*T).F" into "github.com/a/b" and "(*T).F".
+func splitFuncName(name string) (module, function string) {
+	i := strings.LastIndex(name, "/")
+	j := strings.Index(name[i+1:], ".")
+	if j < 0 {
+		return "", name
+	}
+	return name[:i+1+j], name[i+1+j+1:]
+}
+
+func typeName(err error) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", err), "*")
+}
+
+func newEventID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,71 @@
+package report_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/report"
+)
+
+func TestCapture(t *testing.T) {
+	var got *report.Event
+	exp := report.ExporterFunc(func(e *report.Event) error {
+		got = e
+		return nil
+	})
+
+	require.NoError(t, report.Capture(exp, nil))
+	require.Nil(t, got)
+
+	err := errors.WithField(errors.Wrap(io.EOF, "read"), "user_id", 42)
+	require.NoError(t, report.Capture(exp, err))
+
+	require.Len(t, got.EventID, 32)
+	require.Equal(t, "go", got.Platform)
+	require.Equal(t, "read: EOF", got.Message)
+	require.Equal(t, map[string]interface{}{"user_id": 42}, got.Extra)
+	require.Len(t, got.Exception, 1)
+
+	exc := got.Exception[0]
+	require.Equal(t, "errors.errorString", exc.Type)
+	require.Equal(t, "read: EOF", exc.Value)
+
+	frames := exc.Stacktrace.Frames
+	last := frames[len(frames)-1]
+	require.Equal(t, "github.com/quenbyako/errors/report_test", last.Module)
+	require.Equal(t, "TestCapture", last.Function)
+	require.Equal(t, "report_test.go", last.Filename)
+	require.NotZero(t, last.Lineno)
+	require.Equal(t, "runtime", frames[0].Module)
+	require.False(t, frames[0].InApp)
+}
+
+func TestBuilderInApp(t *testing.T) {
+	b := &report.Builder{InAppPrefixes: []string{"github.com/quenbyako/errors"}}
+	event := b.Build(errors.New("failed"))
+
+	frames := event.Exception[0].Stacktrace.Frames
+	require.True(t, frames[len(frames)-1].InApp)
+	require.False(t, frames[0].InApp)
+}
+
+func TestBuilderNil(t *testing.T) {
+	require.Nil(t, report.DefaultBuilder.Build(nil))
+}
+
+func TestBuilderEnrichment(t *testing.T) {
+	remove := errors.AddEnricher(func() []errors.Field {
+		return []errors.Field{{Key: "region", Value: "eu-west-1"}, {Key: "pid", Value: 42}}
+	})
+	defer remove()
+
+	event := report.DefaultBuilder.Build(errors.WithField(errors.New("boom"), "user_id", 1))
+	require.Equal(t, map[string]string{"region": "eu-west-1", "pid": "42"}, event.Tags)
+	require.Equal(t, map[string]interface{}{"user_id": 1}, event.Extra)
+
+	remove()
+	require.Nil(t, report.DefaultBuilder.Build(errors.New("boom")).Tags)
+}
@@ -0,0 +1,69 @@
+//go:build go1.18
+
+package errors
+
+// Result is a single carrier of (value, error) pair, e.g. for pipelines,
+// which pass results of operations through channels:
+//
+//	results := make(chan errors.Result[*User])
+//	go func() { results <- errors.ResultOf(loadUser(42)) }()
+//
+//	user, err := (<-results).Unwrap()
+//
+// Zero value is a successful result with zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns successful result with value v.
+func Ok[T any](v T) Result[T] { return Result[T]{value: v} }
+
+// Err returns failed result with err. If err has no stack trace, Err also
+// records the stack trace at the point it was called. If err is nil, Err
+// returns successful result with zero value of T.
+func Err[T any](err error) Result[T] {
+	if err != nil && Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	return Result[T]{err: err}
+}
+
+// ResultOf converts (v, err) pair into result: if err is nil, result is
+// successful with value v, otherwise it's failed as Err would return (value
+// is dropped).
+func ResultOf[T any](v T, err error) Result[T] {
+	if err == nil {
+		return Result[T]{value: v}
+	}
+	if Stack(err) == nil {
+		err = wStack(err, 1)
+	}
+	return Result[T]{err: err}
+}
+
+// Unwrap returns value and error of r.
+func (r Result[T]) Unwrap() (T, error) { return r.value, r.err }
+
+// Err returns error of r, or nil, if r is successful.
+func (r Result[T]) Err() error { return r.err }
+
+// IsOk reports whether r is successful.
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// MustGet returns value of r, if it's successful, otherwise it panics the
+// same way as Must does, so inside of functions with deferred Handle error of
+// r is returned by the function.
+func (r Result[T]) MustGet() T {
+	check(r.err, 1)
+	return r.value
+}
+
+// Map returns result of fn applied to value of r, if r is successful,
+// otherwise it returns failed result with error of r.
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	return Result[U]{value: fn(r.value)}
+}
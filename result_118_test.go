@@ -0,0 +1,75 @@
+//go:build go1.18
+
+package errors_test
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestResult(t *testing.T) {
+	ok := errors.Ok(42)
+	require.True(t, ok.IsOk())
+	require.NoError(t, ok.Err())
+	v, err := ok.Unwrap()
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+	require.Equal(t, 42, ok.MustGet())
+
+	failed := errors.Err[int](io.EOF)
+	require.False(t, failed.IsOk())
+	require.ErrorIs(t, failed.Err(), io.EOF)
+	require.Equal(t, errors.PkgName+".TestResult", funcName(errors.Stack(failed.Err())[0]))
+	v, err = failed.Unwrap()
+	require.Error(t, err)
+	require.Zero(t, v)
+
+	require.True(t, errors.Err[int](nil).IsOk())
+
+	var zero errors.Result[string]
+	require.True(t, zero.IsOk())
+}
+
+func TestResultOf(t *testing.T) {
+	r := errors.ResultOf(strconv.Atoi("42"))
+	require.Equal(t, 42, r.MustGet())
+
+	r = errors.ResultOf(strconv.Atoi("nope"))
+	require.False(t, r.IsOk())
+	var numErr *strconv.NumError
+	require.ErrorAs(t, r.Err(), &numErr)
+	require.NotEmpty(t, errors.Stack(r.Err()))
+
+	own := errors.New("boom")
+	require.Same(t, own, errors.ResultOf(0, own).Err())
+}
+
+func TestResultMap(t *testing.T) {
+	s := errors.Map(errors.Ok(42), strconv.Itoa)
+	require.Equal(t, "42", s.MustGet())
+
+	failed := errors.Err[int](io.EOF)
+	s = errors.Map(failed, strconv.Itoa)
+	require.Same(t, failed.Err(), s.Err())
+}
+
+func resultMustGet(r errors.Result[int]) (v int, err error) {
+	defer errors.Handle(&err)
+	return r.MustGet() + 1, nil
+}
+
+func TestResultMustGet(t *testing.T) {
+	v, err := resultMustGet(errors.Ok(1))
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+
+	_, err = resultMustGet(errors.Err[int](io.EOF))
+	require.ErrorIs(t, err, io.EOF)
+
+	require.Panics(t, func() { errors.Err[int](io.EOF).MustGet() })
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+type withRetry struct {
+	cause     error
+	retryable bool
+	after     time.Duration
+	hasAfter  bool
+}
+
+// Permanent marks err as permanent failure, which must not be retried.
+// If err is nil, Permanent returns nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withRetry{cause: err}
+}
+
+// Transient marks err as temporary failure, so operation can be retried.
+// If err is nil, Transient returns nil.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withRetry{cause: err, retryable: true}
+}
+
+// WithRetryAfter marks err as temporary failure, so operation can be retried
+// after d.
+// If err is nil, WithRetryAfter returns nil.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &withRetry{cause: err, retryable: true, after: d, hasAfter: true}
+}
+
+func (w *withRetry) Error() string   { return w.cause.Error() }
+func (w *withRetry) Unwrap() error   { return w.cause }
+func (w *withRetry) Retryable() bool { return w.retryable }
+func (w *withRetry) message() string { return "" }
+
+func (w *withRetry) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withRetry) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// IsRetryable reports whether the outermost error in err's chain, which
+// implements Retryable() bool method (e.g. created by Permanent, Transient or
+// WithRetryAfter), is retryable. If there is no such error, IsRetryable
+// returns false.
+func IsRetryable(err error) bool {
+	for ; err != nil; err = Unwrap(err) {
+		if r, ok := err.(interface{ Retryable() bool }); ok {
+			return r.Retryable()
+		}
+	}
+	return false
+}
+
+// RetryAfter returns delay, after which operation can be retried, if the
+// outermost retry marker in err's chain was created by WithRetryAfter.
+func RetryAfter(err error) (time.Duration, bool) {
+	for ; err != nil; err = Unwrap(err) {
+		if r, ok := err.(*withRetry); ok {
+			return r.after, r.hasAfter
+		}
+	}
+	return 0, false
+}
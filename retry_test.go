@@ -0,0 +1,43 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestRetryNil(t *testing.T) {
+	require.Nil(t, errors.Permanent(nil))
+	require.Nil(t, errors.Transient(nil))
+	require.Nil(t, errors.WithRetryAfter(nil, time.Second))
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantRetryable bool
+		wantAfter     time.Duration
+		wantAfterOk   bool
+	}{
+		{"nil", nil, false, 0, false},
+		{"unmarked", io.EOF, false, 0, false},
+		{"permanent", errors.Permanent(io.EOF), false, 0, false},
+		{"transient", errors.Wrap(errors.Transient(io.EOF), "read"), true, 0, false},
+		{"retry after", errors.WithRetryAfter(io.EOF, time.Second), true, time.Second, true},
+		{"outer wins", errors.Permanent(errors.WithRetryAfter(io.EOF, time.Second)), false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantRetryable, errors.IsRetryable(tt.err))
+			after, ok := errors.RetryAfter(tt.err)
+			require.Equal(t, tt.wantAfter, after)
+			require.Equal(t, tt.wantAfterOk, ok)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package errors
+
+import "sync/atomic"
+
+// sampledOutStack is a stack trace of errors, which stack capture was
+// skipped by sampling. It's non-nil, so SampledOut can distinguish it from
+// absent stack.
+var sampledOutStack = StackTrace{}
+
+var (
+	sampleRate    uint64
+	sampleCounter uint64
+)
+
+// SetStackSampling enables sampling of stack capture: only each n-th error,
+// created with New, Errorf, Wrap, WithStack and so on, records its stack
+// trace, while the rest ones are cheap values without stack. It's useful for
+// hot error paths, where thousands of identical traces per second are
+// wasteful. n <= 1 disables sampling, so every error records its stack
+// trace.
+//
+// Errors created with options (see NewWithOptions) and traces returned by
+// Callers are not sampled.
+func SetStackSampling(n int) {
+	if n < 1 {
+		n = 1
+	}
+	atomic.StoreUint64(&sampleRate, uint64(n))
+}
+
+// sampleStack reports whether next stack trace must be recorded.
+func sampleStack() bool {
+	rate := atomic.LoadUint64(&sampleRate)
+	if rate <= 1 {
+		return true
+	}
+	return atomic.AddUint64(&sampleCounter, 1)%rate == 0
+}
+
+// SampledOut reports whether err has no stack trace, because its capture was
+// skipped by sampling (see SetStackSampling).
+func SampledOut(err error) bool {
+	res := false
+	for ; err != nil; err = Unwrap(err) {
+		stack, ok := stackOf(err)
+		if !ok {
+			continue
+		}
+		switch {
+		case len(stack) > 0:
+			return false
+		case stack != nil:
+			res = true
+		}
+	}
+	return res
+}
@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetStackSampling(t *testing.T) {
+	defer errors.SetStackSampling(0)
+	errors.SetStackSampling(4)
+
+	var withStack, sampledOut int
+	for i := 0; i < 100; i++ {
+		err := errors.New("error")
+		if errors.Stack(err) != nil {
+			withStack++
+			require.False(t, errors.SampledOut(err))
+		} else {
+			sampledOut++
+			require.True(t, errors.SampledOut(err))
+			require.Equal(t, "error", fmt.Sprintf("%+v", err))
+		}
+	}
+	require.Equal(t, 25, withStack)
+	require.Equal(t, 75, sampledOut)
+
+	require.NotNil(t, errors.Stack(errors.NewWithOptions("error")))
+	require.NotNil(t, errors.Callers(0))
+
+	errors.SetStackSampling(0)
+	require.NotNil(t, errors.Stack(errors.New("error")))
+}
+
+func TestSampledOut(t *testing.T) {
+	require.False(t, errors.SampledOut(nil))
+	require.False(t, errors.SampledOut(io.EOF))
+	require.False(t, errors.SampledOut(errors.NoStack("error")))
+	require.False(t, errors.SampledOut(errors.New("error")))
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// DefaultPublicMessage is a message of sanitized error, if policy doesn't
+// define its own one.
+const DefaultPublicMessage = "internal error"
+
+// SanitizePolicy describes which parts of error are allowed to leave the
+// service boundary after Sanitize call.
+type SanitizePolicy struct {
+	// PublicMessage is a message of sanitized error. If it's empty, message,
+	// attached by WithSafeMessage, or DefaultPublicMessage will be used (see
+	// Public).
+	PublicMessage string
+	// KeepMessage keeps original error message instead of PublicMessage.
+	KeepMessage bool
+	// KeepCode keeps error code (see Code) of the original error.
+	KeepCode bool
+	// KeepKind keeps kind (see KindOf) of the original error.
+	KeepKind bool
+	// KeepHints keeps hints (see Hints) of the original error.
+	KeepHints bool
+	// Preserve reports whether error in the chain is safe to expose to
+	// clients (e.g. public sentinel values). Is and As will still find first
+	// matched error, but it's not exposed by Unwrap. As doesn't look into
+	// causes of matched error.
+	Preserve func(error) bool
+}
+
+// sanitized is an error which is safe to return to external clients: it
+// contains no stack trace and no internal messages.
+type sanitized struct {
+	msg   string
+	cause error
+}
+
+// Sanitize returns an error, which is safe to return to external clients:
+// stack traces and internal messages are stripped according to the policy.
+// Original error is not modified, so it can be logged in full.
+// If err is nil, Sanitize returns nil.
+func Sanitize(err error, policy SanitizePolicy) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := policy.PublicMessage
+	if msg == "" {
+		msg = Public(err)
+	}
+	if policy.KeepMessage {
+		msg = err.Error()
+	}
+
+	var cause error
+	if policy.Preserve != nil {
+		for e := err; e != nil; e = Unwrap(e) {
+			if policy.Preserve(e) {
+				cause = e
+				break
+			}
+		}
+	}
+
+	res := error(&sanitized{msg: msg, cause: cause})
+	if policy.KeepHints {
+		hints := Hints(err)
+		for i := len(hints) - 1; i >= 0; i-- {
+			res = WithHint(res, hints[i])
+		}
+	}
+	if kind := KindOf(err); kind != KindUnknown && policy.KeepKind {
+		res = WithKind(res, kind)
+	}
+	if code, ok := Code(err); ok && policy.KeepCode {
+		res = WithCode(res, code)
+	}
+	return res
+}
+
+func (s *sanitized) Error() string   { return s.msg }
+func (s *sanitized) message() string { return s.msg }
+
+func (s *sanitized) Is(target error) bool { return s.cause != nil && Is(s.cause, target) }
+
+// As matches only preserved error itself, so types of its causes, which may
+// carry internal details, stay hidden.
+func (s *sanitized) As(target interface{}) bool {
+	if s.cause == nil {
+		return false
+	}
+	val := reflect.ValueOf(target)
+	if val.Kind() == reflect.Ptr && !val.IsNil() && reflect.TypeOf(s.cause).AssignableTo(val.Type().Elem()) {
+		val.Elem().Set(reflect.ValueOf(s.cause))
+		return true
+	}
+	x, ok := s.cause.(interface{ As(interface{}) bool })
+	return ok && x.As(target)
+}
+
+func (s *sanitized) Format(st fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		io.WriteString(st, s.msg)
+	case 'q':
+		fmt.Fprintf(st, "%q", s.msg)
+	}
+}
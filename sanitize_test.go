@@ -0,0 +1,106 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSanitizeNil(t *testing.T) {
+	require.Nil(t, errors.Sanitize(nil, errors.SanitizePolicy{}))
+}
+
+func TestSanitize(t *testing.T) {
+	errPublic := stderrors.New("not found")
+	internal := errors.Wrap(errors.WithMessage(errPublic, "SELECT * FROM users"), "loading user")
+
+	tests := []struct {
+		name    string
+		policy  errors.SanitizePolicy
+		want    string
+		wantIs  bool
+		wantNil bool
+	}{{
+		name:   "default",
+		policy: errors.SanitizePolicy{},
+		want:   errors.DefaultPublicMessage,
+	}, {
+		name:   "public message",
+		policy: errors.SanitizePolicy{PublicMessage: "user not found"},
+		want:   "user not found",
+	}, {
+		name:   "keep message",
+		policy: errors.SanitizePolicy{KeepMessage: true},
+		want:   "loading user: SELECT * FROM users: not found",
+	}, {
+		name: "preserve sentinel",
+		policy: errors.SanitizePolicy{
+			PublicMessage: "user not found",
+			Preserve:      func(err error) bool { return err == errPublic },
+		},
+		want:   "user not found",
+		wantIs: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.Sanitize(internal, tt.policy)
+			require.Equal(t, tt.want, err.Error())
+			require.Equal(t, tt.want, fmt.Sprintf("%+v", err))
+			require.Nil(t, errors.Stack(err))
+			require.Equal(t, tt.wantIs, stderrors.Is(err, errPublic))
+			require.False(t, stderrors.Is(err, io.EOF))
+		})
+	}
+}
+
+func TestSanitizeKeep(t *testing.T) {
+	internal := errors.WithCode(errors.WithKind(errors.WithHint(errors.WithHint(
+		errors.New("SELECT * FROM users"), "check user id"), "retry later"), errors.KindNotFound), 404)
+
+	err := errors.Sanitize(internal, errors.SanitizePolicy{})
+	code, ok := errors.Code(err)
+	require.False(t, ok)
+	require.Zero(t, code)
+	require.Equal(t, errors.KindUnknown, errors.KindOf(err))
+	require.Empty(t, errors.Hints(err))
+
+	err = errors.Sanitize(internal, errors.SanitizePolicy{KeepCode: true, KeepKind: true, KeepHints: true})
+	require.EqualError(t, err, errors.DefaultPublicMessage)
+	require.Nil(t, errors.Stack(err))
+	code, ok = errors.Code(err)
+	require.True(t, ok)
+	require.Equal(t, 404, code)
+	require.Equal(t, errors.KindNotFound, errors.KindOf(err))
+	require.Equal(t, []string{"retry later", "check user id"}, errors.Hints(err))
+}
+
+type queryError struct{ query string }
+
+func (e *queryError) Error() string { return "query failed: " + e.query }
+
+func TestSanitizePreserveHidesCauses(t *testing.T) {
+	errPublic := errors.NoStack("not found")
+	query := &queryError{query: "SELECT * FROM users"}
+	internal := errors.Wrap(errors.WithOriginal(errPublic, query), "loading user")
+
+	err := errors.Sanitize(internal, errors.SanitizePolicy{
+		Preserve: func(err error) bool { return stderrors.Is(err, errPublic) },
+	})
+	require.ErrorIs(t, err, errPublic)
+
+	var target *queryError
+	require.False(t, stderrors.As(err, &target))
+	require.Nil(t, target)
+
+	err = errors.Sanitize(errors.Wrap(query, "loading user"), errors.SanitizePolicy{
+		Preserve: func(err error) bool { return err == query },
+	})
+	require.True(t, stderrors.As(err, &target))
+	require.Same(t, query, target)
+}
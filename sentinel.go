@@ -0,0 +1,131 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Sentinel is an error value, which is intended to be declared at package
+// level and compared with Is. Unlike regular sentinel values, it can be
+// instantiated with parameters, which are rendered in message and surface as
+// structured fields, but Is still matches the base value:
+//
+//	var ErrQuotaExceeded = errors.NewSentinel("quota exceeded")
+//
+//	err := ErrQuotaExceeded.With("bytes", n)
+//	errors.Is(err, ErrQuotaExceeded) // true
+type Sentinel struct {
+	msg string
+}
+
+// NewSentinel returns a new sentinel error with the supplied message. Stack
+// trace is not recorded.
+func NewSentinel(text string) *Sentinel { return &Sentinel{msg: text} }
+
+func (s *Sentinel) Error() string { return s.msg }
+
+// With returns an instance of sentinel with the supplied parameters as
+// alternating keys and values. With also records the stack trace at the
+// point it was called.
+func (s *Sentinel) With(keyvals ...interface{}) error {
+	return newWithParams(s, keyvals, 1)
+}
+
+// Here returns an instance of sentinel with the stack trace recorded at the
+// point it was called:
+//
+//	return ErrQuotaExceeded.Here()
+func (s *Sentinel) Here() error { return newWithParams(s, nil, 1) }
+
+// Const is an error, which can be declared as a constant. Like Sentinel, it
+// doesn't have stack trace, so the trace can be attached at the point, where
+// error is actually returned, with Here, With or by wrapping:
+//
+//	const ErrNotFound = errors.Const("not found")
+//
+//	return ErrNotFound.Here()
+type Const string
+
+func (c Const) Error() string { return string(c) }
+
+// With returns an instance of c with the supplied parameters as alternating
+// keys and values and stack trace recorded at the point it was called. See
+// Sentinel.With for details.
+func (c Const) With(keyvals ...interface{}) error { return newWithParams(c, keyvals, 1) }
+
+// Here returns an instance of c with the stack trace recorded at the point
+// it was called.
+func (c Const) Here() error { return newWithParams(c, nil, 1) }
+
+type withParams struct {
+	base   error
+	params []Field
+	stack  StackTrace
+}
+
+func newWithParams(base error, keyvals []interface{}, extraSkip uint) error {
+	return created(&withParams{
+		base:   base,
+		params: kvToFields(keyvals),
+		stack:  callers(1 + extraSkip),
+	})
+}
+
+func (w *withParams) Unwrap() error          { return w.base }
+func (w *withParams) stackTrace() StackTrace { return w.stack }
+func (w *withParams) fields() []Field        { return w.params }
+
+// message is empty, as message of instance is message of its base, which is
+// the next error in the chain.
+func (w *withParams) message() string { return "" }
+
+func (w *withParams) Error() string {
+	if len(w.params) == 0 {
+		return w.base.Error()
+	}
+	return w.base.Error() + " (" + formatFields(w.params) + ")"
+}
+
+func (w *withParams) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && len(w.stack) > 0 {
+			io.WriteString(s, w.Error()+"\n")
+			w.stack.Format(s, verb)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	}
+}
+
+func formatFields(fields []Field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s=%v", f.Key, f.Value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// badKey is a key for value without pair in alternating key/value list.
+const badKey = "!BADKEY"
+
+func kvToFields(keyvals []interface{}) []Field {
+	res := make([]Field, 0, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 == len(keyvals) {
+			res = append(res, Field{Key: badKey, Value: keyvals[i]})
+			break
+		}
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		res = append(res, Field{Key: key, Value: keyvals[i+1]})
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+var errQuotaExceeded = errors.NewSentinel("quota exceeded")
+
+func TestSentinel(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       string
+		wantFields map[string]interface{}
+	}{{
+		name:       "bare",
+		err:        errQuotaExceeded,
+		want:       "quota exceeded",
+		wantFields: map[string]interface{}{},
+	}, {
+		name:       "no params",
+		err:        errQuotaExceeded.With(),
+		want:       "quota exceeded",
+		wantFields: map[string]interface{}{},
+	}, {
+		name:       "params",
+		err:        errQuotaExceeded.With("bytes", 1024, "limit", "1KiB"),
+		want:       "quota exceeded (bytes=1024, limit=1KiB)",
+		wantFields: map[string]interface{}{"bytes": 1024, "limit": "1KiB"},
+	}, {
+		name:       "odd params",
+		err:        errQuotaExceeded.With("bytes"),
+		want:       "quota exceeded (!BADKEY=bytes)",
+		wantFields: map[string]interface{}{"!BADKEY": "bytes"},
+	}, {
+		name:       "wrapped",
+		err:        errors.Wrap(errQuotaExceeded.With("bytes", 1024), "upload"),
+		want:       "upload: quota exceeded (bytes=1024)",
+		wantFields: map[string]interface{}{"bytes": 1024},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.err.Error())
+			require.True(t, stderrors.Is(tt.err, errQuotaExceeded))
+			var target *errors.Sentinel
+			require.True(t, stderrors.As(tt.err, &target))
+			require.Same(t, errQuotaExceeded, target)
+			require.Equal(t, tt.wantFields, errors.Fields(tt.err))
+		})
+	}
+}
+
+func TestSentinelStack(t *testing.T) {
+	require.Nil(t, errors.Stack(errQuotaExceeded))
+
+	err := errQuotaExceeded.With("bytes", 1024)
+	require.NotNil(t, errors.Stack(err))
+	require.Regexp(t, `^quota exceeded \(bytes=1024\)\n.+TestSentinelStack\n`, fmt.Sprintf("%+v", err))
+}
+
+const errConstNotFound = errors.Const("not found")
+
+func TestSentinelHere(t *testing.T) {
+	tests := []struct {
+		name string
+		base error
+		err  error
+	}{
+		{"sentinel", errQuotaExceeded, errQuotaExceeded.Here()},
+		{"const", errConstNotFound, errConstNotFound.Here()},
+		{"const with", errConstNotFound, errConstNotFound.With("id", 1)},
+		{"const wrapped", errConstNotFound, errors.Wrap(errConstNotFound, "get")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, stderrors.Is(tt.err, tt.base))
+			require.Nil(t, errors.Stack(tt.base))
+			require.NotNil(t, errors.Stack(tt.err))
+			require.Equal(t, errors.PkgName+".TestSentinelHere", funcName(errors.Stack(tt.err)[0]))
+		})
+	}
+
+	require.EqualError(t, errConstNotFound.With("id", 1), "not found (id=1)")
+	require.Equal(t, map[string]interface{}{"id": 1}, errors.Fields(errConstNotFound.With("id", 1)))
+}
@@ -0,0 +1,90 @@
+package errors
+
+import "fmt"
+
+// Level is a severity of failure. Zero value is LevelError, so errors
+// without severity are regular errors. Levels are ordered, so they can be
+// compared: LevelWarning < LevelError < LevelCritical.
+type Level int8
+
+const (
+	// LevelWarning means soft failure, e.g. partial degradation, which
+	// doesn't break the operation.
+	LevelWarning Level = iota - 1
+	// LevelError means regular failure of the operation.
+	LevelError
+	// LevelCritical means failure, which requires immediate attention.
+	LevelCritical
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelWarning:
+		return "warning"
+	case LevelError:
+		return "error"
+	case LevelCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("Level(%d)", l)
+	}
+}
+
+// parseLevel returns level by its name, returned by String.
+func parseLevel(name string) (Level, bool) {
+	for _, l := range []Level{LevelWarning, LevelError, LevelCritical} {
+		if l.String() == name {
+			return l, true
+		}
+	}
+	return LevelError, false
+}
+
+type withSeverity struct {
+	cause error
+	level Level
+}
+
+// WithSeverity annotates err with severity level, which can be extracted
+// later with Severity. Logging integrations use it to select log level.
+// If err is nil, WithSeverity returns nil.
+func WithSeverity(err error, level Level) error {
+	if err == nil {
+		return nil
+	}
+	return &withSeverity{cause: err, level: level}
+}
+
+// Warn marks err as soft failure, it's a shorthand for
+// WithSeverity(err, LevelWarning).
+// If err is nil, Warn returns nil.
+func Warn(err error) error { return WithSeverity(err, LevelWarning) }
+
+// Critical marks err as critical failure, it's a shorthand for
+// WithSeverity(err, LevelCritical).
+// If err is nil, Critical returns nil.
+func Critical(err error) error { return WithSeverity(err, LevelCritical) }
+
+func (w *withSeverity) Error() string        { return w.cause.Error() }
+func (w *withSeverity) Unwrap() error        { return w.cause }
+func (w *withSeverity) message() string      { return "" }
+func (w *withSeverity) errorSeverity() Level { return w.level }
+
+func (w *withSeverity) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withSeverity) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Severity returns the outermost severity level in err's chain (see
+// WithSeverity), so callers can escalate or downgrade failures of their
+// dependencies. If there is no severity, Severity returns LevelError.
+func Severity(err error) Level {
+	for ; err != nil; err = Unwrap(err) {
+		if s, ok := err.(interface{ errorSeverity() Level }); ok {
+			return s.errorSeverity()
+		}
+	}
+	return LevelError
+}
+
+// IsWarning reports whether err is a soft failure, see Warn.
+func IsWarning(err error) bool { return err != nil && Severity(err) == LevelWarning }
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSeverity(t *testing.T) {
+	require.Equal(t, errors.LevelError, errors.Severity(nil))
+	require.Equal(t, errors.LevelError, errors.Severity(io.EOF))
+	require.Nil(t, errors.Warn(nil))
+	require.False(t, errors.IsWarning(nil))
+
+	soft := errors.Wrap(errors.Warn(io.EOF), "refresh cache")
+	require.Equal(t, errors.LevelWarning, errors.Severity(soft))
+	require.True(t, errors.IsWarning(soft))
+	require.Equal(t, "refresh cache: EOF", soft.Error())
+	require.ErrorIs(t, soft, io.EOF)
+
+	require.Equal(t, errors.LevelCritical, errors.Severity(errors.Critical(soft)), "outer severity wins")
+	require.True(t, errors.LevelWarning < errors.LevelError && errors.LevelError < errors.LevelCritical)
+
+	require.Equal(t, "warning", errors.LevelWarning.String())
+	require.Equal(t, "critical", errors.LevelCritical.String())
+	require.Equal(t, "Level(5)", errors.Level(5).String())
+}
+
+func TestSeverityJSON(t *testing.T) {
+	data, err := errors.ToJSON(errors.Warn(errors.New("stale")))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"severity":"warning"`)
+
+	back, err := errors.FromJSON(data)
+	require.NoError(t, err)
+	require.Equal(t, errors.LevelWarning, errors.Severity(back))
+}
+
+func TestSeverityRemapper(t *testing.T) {
+	errDegraded := errors.New("degraded")
+	r := errors.NewRemapper(errors.SeverityRemapper(errors.LevelWarning, errDegraded))
+	require.Equal(t, errDegraded, r.Remap(errors.Warn(io.EOF)))
+	require.Equal(t, io.EOF, r.Remap(io.EOF))
+}
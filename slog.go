@@ -0,0 +1,113 @@
+//go:build go1.21
+
+package errors
+
+import (
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// SlogAttr returns slog attribute with "error" key, which contains message,
+// cause chain, attached fields and compact stack trace of err.
+func SlogAttr(err error) slog.Attr {
+	return slog.Attr{Key: "error", Value: logValue(err)}
+}
+
+// SlogLevel returns slog level of err according to its severity (see
+// Severity): LevelWarn for warnings, LevelError for regular errors and
+// LevelError+4 for critical ones. If err is nil, SlogLevel returns LevelInfo.
+func SlogLevel(err error) slog.Level {
+	if err == nil {
+		return slog.LevelInfo
+	}
+	switch Severity(err) {
+	case LevelWarning:
+		return slog.LevelWarn
+	case LevelCritical:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+func (f *fundamental) LogValue() slog.Value     { return logValue(f) }
+func (w *withStack) LogValue() slog.Value       { return logValue(w) }
+func (a *adopted) LogValue() slog.Value         { return logValue(a) }
+func (w *withMessage) LogValue() slog.Value     { return logValue(w) }
+func (w *withField) LogValue() slog.Value       { return logValue(w) }
+func (w *withParams) LogValue() slog.Value      { return logValue(w) }
+func (w *withCode) LogValue() slog.Value        { return logValue(w) }
+func (w *withHTTPStatus) LogValue() slog.Value  { return logValue(w) }
+func (w *withRetry) LogValue() slog.Value       { return logValue(w) }
+func (j *joined) LogValue() slog.Value          { return logValue(j) }
+func (o *opaque) LogValue() slog.Value          { return logValue(o) }
+func (s *sanitized) LogValue() slog.Value       { return logValue(s) }
+func (r *remote) LogValue() slog.Value          { return logValue(r) }
+func (r *remoteMulti) LogValue() slog.Value     { return logValue(r) }
+func (r *remapped) LogValue() slog.Value        { return logValue(r) }
+func (t *templated) LogValue() slog.Value       { return logValue(t) }
+func (w *withMessageKey) LogValue() slog.Value  { return logValue(w) }
+func (w *withSafeMessage) LogValue() slog.Value { return logValue(w) }
+func (w *withKind) LogValue() slog.Value        { return logValue(w) }
+func (r *redacted) LogValue() slog.Value        { return logValue(r) }
+func (e *ValidationError) LogValue() slog.Value { return logValue(e) }
+func (w *withExitCode) LogValue() slog.Value    { return logValue(w) }
+func (w *withTime) LogValue() slog.Value        { return logValue(w) }
+func (w *withLabels) LogValue() slog.Value      { return logValue(w) }
+func (r *remapTraced) LogValue() slog.Value     { return logValue(r) }
+func (w *withSeverity) LogValue() slog.Value    { return logValue(w) }
+func (w *withTags) LogValue() slog.Value        { return logValue(w) }
+func (w *withBuildInfo) LogValue() slog.Value   { return logValue(w) }
+func (w *withHint) LogValue() slog.Value        { return logValue(w) }
+func (w *wrapError) LogValue() slog.Value       { return logValue(w) }
+func (w *wrapErrors) LogValue() slog.Value      { return logValue(w) }
+
+func logValue(err error) slog.Value {
+	if err == nil {
+		return slog.Value{}
+	}
+	attrs := []slog.Attr{slog.String("message", err.Error())}
+
+	var chain []string
+	for e := err; e != nil; e = Unwrap(e) {
+		if msg := ownMessage(e); msg != "" {
+			chain = append(chain, msg)
+		}
+		if IsTruncated(e) {
+			// causes repeat messages, which were truncated
+			break
+		}
+	}
+	if len(chain) > 1 {
+		attrs = append(attrs, slog.Any("chain", chain))
+	}
+	if level := Severity(err); level != LevelError {
+		attrs = append(attrs, slog.String("severity", level.String()))
+	}
+
+	if fields := Fields(err); len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fieldAttrs := make([]slog.Attr, len(keys))
+		for i, k := range keys {
+			fieldAttrs[i] = slog.Any(k, fields[k])
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fieldAttrs...)})
+	}
+
+	if stack := Stack(err); len(stack) > 0 {
+		frames := make([]string, len(stack))
+		for i, f := range stack {
+			file, line, name := f.FuncInfo()
+			frames[i] = name + " " + path.Base(file) + ":" + strconv.Itoa(line)
+		}
+		attrs = append(attrs, slog.Any("stack", frames))
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,75 @@
+//go:build go1.21
+
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSlog(t *testing.T) {
+	err := errors.WithField(errors.Wrap(io.EOF, "read"), "user_id", 42)
+
+	for name, attr := range map[string]slog.Attr{
+		"LogValuer": slog.Any("error", err),
+		"SlogAttr":  errors.SlogAttr(err),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", attr)
+
+			var got struct {
+				Error struct {
+					Message string
+					Chain   []string
+					Fields  map[string]interface{}
+					Stack   []string
+				}
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			require.Equal(t, "read: EOF", got.Error.Message)
+			require.Equal(t, []string{"read", "EOF"}, got.Error.Chain)
+			require.Equal(t, map[string]interface{}{"user_id": float64(42)}, got.Error.Fields)
+			require.NotEmpty(t, got.Error.Stack)
+			require.Regexp(t, `^`+errors.PkgName+`\.TestSlog slog_test\.go:\d+$`, got.Error.Stack[0])
+		})
+	}
+}
+
+func TestSlogAttrForeign(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", errors.SlogAttr(io.EOF))
+	require.Contains(t, buf.String(), `"error":{"message":"EOF"}`)
+}
+
+func TestSlogLevel(t *testing.T) {
+	require.Equal(t, slog.LevelInfo, errors.SlogLevel(nil))
+	require.Equal(t, slog.LevelError, errors.SlogLevel(io.EOF))
+	require.Equal(t, slog.LevelWarn, errors.SlogLevel(errors.Warn(io.EOF)))
+	require.Equal(t, slog.LevelError+4, errors.SlogLevel(errors.Critical(io.EOF)))
+}
+
+func TestSlogTruncated(t *testing.T) {
+	payload := strings.Repeat("x", 200)
+	err := errors.Wrap(errors.Truncate(errors.Wrap(errors.New(payload), "query"), errors.Limits{Message: 10}), "handle")
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("failed", errors.SlogAttr(err))
+	require.NotContains(t, buf.String(), payload)
+
+	var res struct {
+		Error struct {
+			Chain []string `json:"chain"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	require.Equal(t, []string{"handle", "query: xxx... (truncated, 207 bytes total)"}, res.Error.Chain)
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sourceContext is a number of lines printed before and after the line of
+// the frame in source snippets.
+const sourceContext = 2
+
+// maxSourceFiles is maximum number of files, cached by sourceLines.
+const maxSourceFiles = 64
+
+// sources caches lines of source files, read for snippets. Files, which
+// can't be read, are cached as nil, so they are not retried. When cache
+// becomes full, it is simply dropped, the same way as symbol cache is.
+var sources struct {
+	sync.Mutex
+	files map[string][]string
+}
+
+func sourceLines(file string) []string {
+	sources.Lock()
+	lines, ok := sources.files[file]
+	sources.Unlock()
+	if ok {
+		return lines
+	}
+
+	if data, err := os.ReadFile(file); err == nil {
+		lines = strings.Split(string(bytes.TrimRight(data, "\n")), "\n")
+	}
+
+	sources.Lock()
+	if sources.files == nil || len(sources.files) >= maxSourceFiles {
+		sources.files = make(map[string][]string)
+	}
+	sources.files[file] = lines
+	sources.Unlock()
+	return lines
+}
+
+// sourceSnippet returns lines of the source file around the line of f, each
+// prefixed with its number, and the line itself marked with '>'. If source
+// file is not available, sourceSnippet returns empty string.
+//
+// Only frames of current binary are read: paths of synthetic frames come
+// from decoded data (e.g. errors received from remote peers), so they must
+// never be opened.
+func sourceSnippet(f Frame) string {
+	if f.isSynthetic() {
+		return ""
+	}
+	file, line, _ := f.FuncInfo()
+	if file == unknown {
+		return ""
+	}
+	lines := sourceLines(file)
+	if line <= 0 || line > len(lines) {
+		return ""
+	}
+	from, to := line-sourceContext, line+sourceContext
+	if from < 1 {
+		from = 1
+	}
+	if to > len(lines) {
+		to = len(lines)
+	}
+	width := len(strconv.Itoa(to))
+
+	var buf strings.Builder
+	for i := from; i <= to; i++ {
+		mark := "  "
+		if i == line {
+			mark = "> "
+		}
+		num := strconv.Itoa(i)
+		buf.WriteString("\n\t" + mark + strings.Repeat(" ", width-len(num)) + num + " | " + lines[i-1])
+	}
+	return buf.String()
+}
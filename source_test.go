@@ -0,0 +1,57 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSourceSnippet(t *testing.T) {
+	err := errors.Wrap(io.EOF, "read") // snippet marker
+
+	want := "read: EOF\n" +
+		errors.PkgName + ".TestSourceSnippet\n" +
+		"\t.+/" + errors.PkgNameRaw + "/source_test.go:13\n" +
+		"\t  11 \\| \n" +
+		"\t  12 \\| func TestSourceSnippet\\(t \\*testing.T\\) {\n" +
+		"\t> 13 \\| \terr := errors.Wrap\\(io.EOF, \"read\"\\) // snippet marker\n" +
+		"\t  14 \\| \n" +
+		"\t  15 \\| \twant := \"read: EOF\\\\n\" \\+\n" +
+		"(?s:.*)"
+
+	got := fmt.Sprintf("%#+v", err)
+	if !regexpMatch(want, got) {
+		t.Errorf("%%#+v:\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestSourceSnippetUnavailable(t *testing.T) {
+	var f errors.Frame
+	if err := f.UnmarshalText([]byte("main.main /nonexistent/main.go:42")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "main.main\n\t/nonexistent/main.go:42"
+	if got := fmt.Sprintf("%#+v", f); got != want {
+		t.Errorf("%%#+v: got %q, want %q", got, want)
+	}
+}
+
+func TestSourceSnippetSynthetic(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+
+	// frame, decoded from untrusted data, must not read local files, even if
+	// its path exists
+	var f errors.Frame
+	if err := f.UnmarshalText([]byte("main.main " + file + ":12")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "main.main\n\t" + file + ":12"
+	if got := fmt.Sprintf("%#+v", f); got != want {
+		t.Errorf("%%#+v: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatOpts configures output of Sprint.
+type FormatOpts struct {
+	// MaxFrames limits number of printed frames, zero means no limit.
+	MaxFrames int
+	// SkipStdlib omits frames of standard library (including runtime and
+	// testing packages), so only application frames are printed.
+	SkipStdlib bool
+	// Ellipsis adds "… N more" line, if some frames were cut by MaxFrames.
+	Ellipsis bool
+}
+
+// Sprint renders err with its stack trace (see Stack) in the same format as
+// %+v verb does for stack frames, but limited by opts, e.g. to show only the
+// top application frames in log lines:
+//
+//	errors.Sprint(err, errors.FormatOpts{MaxFrames: 3, SkipStdlib: true, Ellipsis: true})
+//
+//	read config: file not found
+//	main.load
+//		/app/config.go:42
+//	main.run
+//		/app/main.go:30
+//	main.main
+//		/app/main.go:12
+//	… 17 more
+//
+// Global frame filter (see SetGlobalFrameFilter) is applied as well.
+// If err is nil, Sprint returns empty string.
+func Sprint(err error, opts FormatOpts) string {
+	if err == nil {
+		return ""
+	}
+	stack := Stack(err)
+	if filter := globalFrameFilter(); filter != nil {
+		stack = stack.Filter(filter)
+	}
+	if opts.SkipStdlib {
+		stack = stack.Filter(func(f Frame) bool {
+			_, _, name := f.FuncInfo()
+			return !isStdlibFunc(name)
+		})
+	}
+	more := 0
+	if opts.MaxFrames > 0 && len(stack) > opts.MaxFrames {
+		more = len(stack) - opts.MaxFrames
+		stack = stack[:opts.MaxFrames]
+	}
+
+	var b strings.Builder
+	b.WriteString(err.Error())
+	for _, f := range stack {
+		fmt.Fprintf(&b, "\n%+v", f)
+	}
+	if more > 0 && opts.Ellipsis {
+		b.WriteString("\n… " + strconv.Itoa(more) + " more")
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func sprintRecurse(n int) error {
+	if n == 0 {
+		return errors.New("boom")
+	}
+	return sprintRecurse(n - 1)
+}
+
+func TestSprint(t *testing.T) {
+	require.Empty(t, errors.Sprint(nil, errors.FormatOpts{}))
+
+	err := sprintRecurse(5)
+	full := errors.Sprint(err, errors.FormatOpts{})
+	lines := strings.Split(full, "\n")
+	require.Equal(t, "boom", lines[0])
+	require.Len(t, lines, 1+2*len(errors.Stack(err)))
+	require.Equal(t, errors.PkgName+".sprintRecurse", lines[1])
+	require.Contains(t, full, "testing.tRunner")
+
+	got := errors.Sprint(err, errors.FormatOpts{SkipStdlib: true})
+	require.NotContains(t, got, "testing.tRunner")
+	require.NotContains(t, got, "runtime.goexit")
+	require.Contains(t, got, errors.PkgName+".TestSprint")
+
+	got = errors.Sprint(err, errors.FormatOpts{MaxFrames: 2})
+	lines = strings.Split(got, "\n")
+	require.Len(t, lines, 1+2*2)
+	require.NotContains(t, got, "more")
+
+	got = errors.Sprint(err, errors.FormatOpts{MaxFrames: 2, SkipStdlib: true, Ellipsis: true})
+	lines = strings.Split(got, "\n")
+	require.Len(t, lines, 1+2*2+1)
+	require.Equal(t, "… 5 more", lines[len(lines)-1], "sprintRecurse frames and TestSprint remain after skipping stdlib")
+
+	got = errors.Sprint(err, errors.FormatOpts{MaxFrames: 100, Ellipsis: true})
+	require.Equal(t, full, got)
+}
@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+)
+
+// SQLDialect describes, how failures of particular database driver are
+// detected and classified by SQLRemappers.
+type SQLDialect struct {
+	// Codes maps SQLSTATE codes (reported by errors with SQLState() string
+	// method, e.g. errors of pgx and lib/pq drivers) to kinds of failure.
+	Codes map[string]Kind
+	// Messages maps substrings of error messages to kinds of failure. It's
+	// used for drivers, which don't expose error codes by methods.
+	Messages map[string]Kind
+}
+
+var (
+	sqlDialectsMu sync.RWMutex
+	sqlDialects   = map[string]SQLDialect{
+		"postgres": {
+			Codes: map[string]Kind{
+				"23505": KindConflict,     // unique_violation
+				"23503": KindPrecondition, // foreign_key_violation
+				"23502": KindInvalid,      // not_null_violation
+				"23514": KindInvalid,      // check_violation
+				"40001": KindConflict,     // serialization_failure
+				"40P01": KindConflict,     // deadlock_detected
+				"57014": KindCanceled,     // query_canceled
+			},
+		},
+		"mysql": {
+			Messages: map[string]Kind{
+				"Error 1062": KindConflict,     // ER_DUP_ENTRY
+				"Error 1451": KindPrecondition, // ER_ROW_IS_REFERENCED_2
+				"Error 1452": KindPrecondition, // ER_NO_REFERENCED_ROW_2
+				"Error 1048": KindInvalid,      // ER_BAD_NULL_ERROR
+				"Error 1213": KindConflict,     // ER_LOCK_DEADLOCK
+			},
+		},
+		"sqlite3": {
+			Messages: map[string]Kind{
+				"UNIQUE constraint failed":      KindConflict,
+				"FOREIGN KEY constraint failed": KindPrecondition,
+				"NOT NULL constraint failed":    KindInvalid,
+				"CHECK constraint failed":       KindInvalid,
+				"database is locked":            KindConflict,
+			},
+		},
+	}
+)
+
+func init() {
+	sqlDialects["pgx"] = sqlDialects["postgres"]
+	sqlDialects["sqlite"] = sqlDialects["sqlite3"]
+}
+
+// RegisterSQLDialect registers (or replaces) dialect of driver, which is
+// used by SQLRemappers. Dialects of "postgres" (and "pgx"), "mysql" and
+// "sqlite3" (and "sqlite") drivers are registered by default.
+func RegisterSQLDialect(driver string, d SQLDialect) {
+	sqlDialectsMu.Lock()
+	defer sqlDialectsMu.Unlock()
+	sqlDialects[driver] = d
+}
+
+// SQLRemappers returns remapping rules for common database/sql failures of
+// driver, which can be plugged into Remap pipeline:
+//
+//	remapper := errors.NewRemapper(errors.SQLRemappers("postgres")...).KeepOriginal()
+//
+// sql.ErrNoRows is classified as KindNotFound, constraint violations and
+// other failures are classified by dialect of driver (see SQLDialect). Each
+// rule annotates matched error with its kind (see WithKind). If driver is
+// unknown, only driver independent rules are returned.
+func SQLRemappers(driver string) []ErrRemapperFunc {
+	rules := []ErrRemapperFunc{
+		IsRemapperFunc(sql.ErrNoRows, kindConverter(KindNotFound)),
+		IsRemapperFunc(sql.ErrTxDone, kindConverter(KindPrecondition)),
+	}
+
+	sqlDialectsMu.RLock()
+	d, ok := sqlDialects[driver]
+	sqlDialectsMu.RUnlock()
+	if !ok {
+		return rules
+	}
+	if len(d.Codes) > 0 {
+		rules = append(rules, sqlCodeRemapper(d.Codes))
+	}
+	if len(d.Messages) > 0 {
+		rules = append(rules, sqlMessageRemapper(d.Messages))
+	}
+	return rules
+}
+
+func kindConverter(kind Kind) ErrConverter {
+	return func(err error) error { return WithKind(err, kind) }
+}
+
+func sqlCodeRemapper(codes map[string]Kind) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		s, ok := err.(interface{ SQLState() string })
+		if !ok {
+			return nil, false
+		}
+		kind, ok := codes[s.SQLState()]
+		if !ok {
+			return nil, false
+		}
+		return WithKind(err, kind), true
+	}
+}
+
+func sqlMessageRemapper(messages map[string]Kind) ErrRemapperFunc {
+	return func(err error) (error, bool) {
+		msg := err.Error()
+		for substr, kind := range messages {
+			if strings.Contains(msg, substr) {
+				return WithKind(err, kind), true
+			}
+		}
+		return nil, false
+	}
+}
@@ -0,0 +1,53 @@
+package errors_test
+
+import (
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+func TestSQLRemappers(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		err    error
+		want   errors.Kind
+	}{
+		{"no rows", "unknown", errors.Wrap(sql.ErrNoRows, "get user"), errors.KindNotFound},
+		{"tx done", "postgres", sql.ErrTxDone, errors.KindPrecondition},
+		{"postgres unique", "postgres", errors.Wrap(&pgError{"23505"}, "insert"), errors.KindConflict},
+		{"pgx foreign key", "pgx", &pgError{"23503"}, errors.KindPrecondition},
+		{"postgres unmatched", "postgres", &pgError{"42P01"}, errors.KindUnknown},
+		{"mysql duplicate", "mysql", errors.New("Error 1062 (23000): Duplicate entry 'a' for key 'name'"), errors.KindConflict},
+		{"sqlite unique", "sqlite3", errors.New("UNIQUE constraint failed: users.name"), errors.KindConflict},
+		{"unknown driver", "unknown", errors.New("UNIQUE constraint failed: users.name"), errors.KindUnknown},
+		{"other", "postgres", io.EOF, errors.KindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.NewRemapper(errors.SQLRemappers(tt.driver)...).Remap(tt.err)
+			require.Equal(t, tt.want, errors.KindOf(err))
+			require.ErrorIs(t, err, errors.Cause(tt.err))
+		})
+	}
+}
+
+func TestRegisterSQLDialect(t *testing.T) {
+	errors.RegisterSQLDialect("custom", errors.SQLDialect{
+		Codes:    map[string]errors.Kind{"X1": errors.KindRateLimited},
+		Messages: map[string]errors.Kind{"too busy": errors.KindTimeout},
+	})
+	remapper := errors.NewRemapper(errors.SQLRemappers("custom")...)
+	require.Equal(t, errors.KindRateLimited, errors.KindOf(remapper.Remap(&pgError{"X1"})))
+	require.Equal(t, errors.KindTimeout, errors.KindOf(remapper.Remap(errors.New("server too busy"))))
+}
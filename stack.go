@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 const unknown = "unknown"
@@ -23,12 +24,25 @@ func (f Frame) pc() uintptr { return uintptr(f) - 1 }
 // FuncInfo returns the full path to the File and Line number of the source code that contains the
 // function and its name for this Frame's program counter.
 func (f Frame) FuncInfo() (file string, line int, name string) {
+	if f.isSynthetic() {
+		return f.syntheticInfo()
+	}
+	cache := loadSymbolCache()
+	if cache != nil {
+		if info, ok := cache.get(f); ok {
+			return info.file, info.line, info.name
+		}
+	}
 	fn := runtime.FuncForPC(f.pc())
 	if fn == nil {
 		return unknown, 0, unknown
 	}
 	file, line = fn.FileLine(f.pc())
-	return file, line, fn.Name()
+	name = fn.Name()
+	if cache != nil {
+		cache.put(f, frameInfo{file: file, line: line, name: name})
+	}
+	return file, line, name
 }
 
 // Format formats the frame according to the fmt.Formatter interface.
@@ -43,6 +57,8 @@ func (f Frame) FuncInfo() (file string, line int, name string) {
 //    %+s   function name and path of source file relative to the compile time
 //          GOPATH separated by \n\t (<funcname>\n\t<path>)
 //    %+v   equivalent to %+s:%d
+//    %#+v  equivalent to %+v, followed by a snippet of the source code around
+//          the line, if source file is available
 func (f Frame) Format(s fmt.State, verb rune) {
 	file, line, name := f.FuncInfo()
 	switch verb {
@@ -55,7 +71,7 @@ func (f Frame) Format(s fmt.State, verb rune) {
 			}
 			io.WriteString(s, name)
 			io.WriteString(s, "\n\t")
-			io.WriteString(s, file)
+			io.WriteString(s, displayPath(f, file, name))
 		default:
 			io.WriteString(s, path.Base(file))
 		}
@@ -67,6 +83,9 @@ func (f Frame) Format(s fmt.State, verb rune) {
 		f.Format(s, 's')
 		io.WriteString(s, ":")
 		f.Format(s, 'd')
+		if s.Flag('+') && s.Flag('#') {
+			io.WriteString(s, sourceSnippet(f))
+		}
 	}
 }
 
@@ -80,6 +99,28 @@ func (f Frame) MarshalText() ([]byte, error) {
 	return []byte(fmt.Sprintf("%s %s:%d", name, file, line)), nil
 }
 
+// UnmarshalText parses a stacktrace Frame from the text in format of
+// MarshalText. Parsed frame is not backed by program counter of current
+// binary, but formats and marshals exactly as the original one.
+func (f *Frame) UnmarshalText(text []byte) error {
+	s := string(text)
+	if s == unknown || s == "" {
+		*f = 0
+		return nil
+	}
+	i := strings.Index(s, " ")
+	j := strings.LastIndex(s, ":")
+	if i < 0 || j < i {
+		return New("invalid frame: " + strconv.Quote(s))
+	}
+	line, err := strconv.Atoi(s[j+1:])
+	if err != nil {
+		return New("invalid frame line: " + strconv.Quote(s))
+	}
+	*f = syntheticFrame(s[i+1:j], line, s[:i])
+	return nil
+}
+
 // StackTrace is stack of Frames from innermost (newest) to outermost (oldest).
 type StackTrace []Frame
 
@@ -91,7 +132,12 @@ type StackTrace []Frame
 // Format accepts flags that alter the printing of some verbs, as follows:
 //
 //    %+v   Prints filename, function, and line number for each Frame in the stack.
+//    %#v   Prints frames in goroutine dump style, see FormatGoroutine.
+//    %#+v  Same as %+v, but also prints source code snippet of each Frame.
 func (st StackTrace) Format(s fmt.State, verb rune) {
+	if filter := globalFrameFilter(); filter != nil {
+		st = st.Filter(filter)
+	}
 	switch verb {
 	case 'v':
 		switch {
@@ -101,7 +147,7 @@ func (st StackTrace) Format(s fmt.State, verb rune) {
 				io.WriteString(s, "\n")
 			}
 		case s.Flag('#'):
-			fmt.Fprintf(s, "%#v", []Frame(st))
+			st.FormatGoroutine(s)
 		default:
 			st.formatSlice(s, verb)
 		}
@@ -123,19 +169,60 @@ func (st StackTrace) formatSlice(s fmt.State, verb rune) {
 	io.WriteString(s, "]")
 }
 
+// defaultStackDepth is maximum depth of stacktrace to save only important
+// calls and to save some memory.
+const defaultStackDepth = 32
+
+// stackDepth is current maximum depth of stacktrace, see SetDefaultDepth.
+var stackDepth int32 = defaultStackDepth
+
+// SetDefaultDepth sets maximum depth of stack traces, recorded by this
+// package. If n is not positive, default depth (32) is restored.
+func SetDefaultDepth(n int) {
+	if n <= 0 {
+		n = defaultStackDepth
+	}
+	atomic.StoreInt32(&stackDepth, int32(n))
+}
+
 func callers(extraSkip uint) StackTrace {
+	if !sampleStack() {
+		return sampledOutStack
+	}
+	return callersDepth(extraSkip+1, int(atomic.LoadInt32(&stackDepth)))
+}
+
+func callersDepth(extraSkip uint, depth int) StackTrace {
 	// skip calls in stacktrace to ensure that runtime returns only func calls outside this package
 	const defaultSkip uint = 2
-	// maximum depth of stacktrace to save only important calls and to save some memory
-	const depth = 32
 
-	var pcs [depth]uintptr
-	n := runtime.Callers(int(defaultSkip+extraSkip), pcs[:])
+	if fn := loadCallersFunc(); fn != nil {
+		stack := fn(defaultSkip + extraSkip + 1)
+		if len(stack) > depth {
+			stack = stack[:depth]
+		}
+		return stack
+	}
+
+	var buf [defaultStackDepth]uintptr
+	var pooled *[]uintptr
+	pcs := buf[:]
+	if depth > len(buf) {
+		if pooled = getPCs(depth); pooled != nil {
+			pcs = *pooled
+		} else {
+			pcs = make([]uintptr, depth)
+		}
+	} else {
+		pcs = pcs[:depth]
+	}
+	n := runtime.Callers(int(defaultSkip+extraSkip), pcs)
 
-	stack := make(StackTrace, n)
+	stack := allocStack(n)
 	for i := 0; i < n; i++ { // not ranging to avoid allocating
 		stack[i] = Frame(pcs[i])
 	}
+	putPCs(pooled)
 
 	return stack
 }
@@ -1,3 +0,0 @@
-package errors
-
-func Callers(extraSkip uint) StackTrace { return callers(extraSkip + 1) }
@@ -139,7 +139,7 @@ func TestStackTraceFormat(t *testing.T) {
 	}, {
 		nil,
 		"%#v",
-		`\[\]errors.Frame\(nil\)`,
+		"",
 	}, {
 		errors.StackTrace{},
 		"%s",
@@ -155,7 +155,7 @@ func TestStackTraceFormat(t *testing.T) {
 	}, {
 		errors.StackTrace{},
 		"%#v",
-		`\[\]errors.Frame{}`,
+		"",
 	}, {
 		stackyCaller()[:2],
 		"%s",
@@ -174,7 +174,7 @@ func TestStackTraceFormat(t *testing.T) {
 	}, {
 		stackyCaller()[:2],
 		"%#v",
-		`\[\]errors.Frame{stack_test.go:189, stack_test.go:175}`,
+		errors.PkgName + `.stackyCaller\(\.\.\.\)` + "\n\t.+/" + errors.PkgNameRaw + `/stack_test.go:189 \+0x[0-9a-f]+` + "\n" + errors.PkgName + `.TestStackTraceFormat\(\.\.\.\)` + "\n\t.+/" + errors.PkgNameRaw + `/stack_test.go:175 \+0x[0-9a-f]+` + "\n",
 	}}
 
 	for _, tt := range tests {
@@ -194,3 +194,32 @@ func funcname(name string) string {
 	i = strings.Index(name, ".")
 	return name[i+1:]
 }
+
+func TestFrameUnmarshalText(t *testing.T) {
+	var f errors.Frame
+	if err := f.UnmarshalText([]byte("github.com/foo/bar.Baz /src/bar/baz.go:42")); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%s", "baz.go"},
+		{"%d", "42"},
+		{"%n", "Baz"},
+		{"%v", "baz.go:42"},
+		{"%+v", "github.com/foo/bar.Baz\n\t/src/bar/baz.go:42"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, f); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	if err := f.UnmarshalText([]byte("unknown")); err != nil || f != 0 {
+		t.Errorf("unknown: got %v, %v", uintptr(f), err)
+	}
+	if err := f.UnmarshalText([]byte("garbage")); err == nil {
+		t.Errorf("garbage: expected error")
+	}
+}
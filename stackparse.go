@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseStackTrace parses stack trace from text, e.g. from crash report,
+// stored in logs, so it can be formatted and filtered again. Parsing is best
+// effort: it recognizes frames in extended format of this package (%+v),
+//
+//	pkg.Func
+//		/path/to/file.go:42
+//
+// and in format of Go panics and goroutine dumps (see
+// StackTrace.FormatGoroutine),
+//
+//	pkg.Func(0x1, 0x2)
+//		/path/to/file.go:42 +0x1d
+//
+// and skips all the other lines (messages, goroutine headers, etc.). If text
+// has several traces, frames of all of them are returned. Parsed frames are
+// synthetic: they format and marshal as original ones, but are not backed by
+// program counters of current binary.
+func ParseStackTrace(text string) (StackTrace, error) {
+	var res StackTrace
+	var name string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			name = ""
+			continue
+		}
+		if line[0] != '\t' && line[0] != ' ' {
+			name = parseFuncLine(line)
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		file, lineNo, ok := parseFileLine(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		res = append(res, syntheticFrame(file, lineNo, name))
+		name = ""
+	}
+	if len(res) == 0 {
+		return nil, New("no stack frames found")
+	}
+	return res, nil
+}
+
+// parseFuncLine returns function name of frame header line: "pkg.Func",
+// "pkg.Func(0x1, ...)" or "created by pkg.Func in goroutine 1".
+func parseFuncLine(line string) string {
+	line = strings.TrimPrefix(line, "created by ")
+	if i := strings.Index(line, " in goroutine "); i >= 0 {
+		line = line[:i]
+	}
+	if strings.HasSuffix(line, ")") {
+		if i := strings.LastIndex(line, "("); i > 0 {
+			line = line[:i]
+		}
+	}
+	if strings.ContainsAny(line, " \t") {
+		// messages and headers like "goroutine 1 [running]:"
+		return ""
+	}
+	return line
+}
+
+// parseFileLine parses frame location "/path/to/file.go:42", optionally
+// followed by pc offset " +0x1d".
+func parseFileLine(s string) (file string, line int, ok bool) {
+	i := strings.LastIndex(s, ":")
+	if i <= 0 {
+		return "", 0, false
+	}
+	num := s[i+1:]
+	if j := strings.IndexByte(num, ' '); j >= 0 {
+		num = num[:j]
+	}
+	line, err := strconv.Atoi(num)
+	if err != nil {
+		return "", 0, false
+	}
+	return s[:i], line, true
+}
@@ -0,0 +1,74 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestStackTraceFromPCs(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+	pcs := append([]uintptr(nil), st.PCs()...)
+
+	got := errors.StackTraceFromPCs(pcs)
+	require.Equal(t, st, got)
+	pcs[0] = 0
+	require.Equal(t, st[0], got[0], "pcs must be copied")
+	require.Nil(t, errors.StackTraceFromPCs(nil))
+}
+
+func TestParseStackTraceExtended(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+	text := "ooh\n" + fmt.Sprintf("%+v", st)
+
+	got, err := errors.ParseStackTrace(text)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%+v", st), fmt.Sprintf("%+v", got))
+}
+
+func TestParseStackTraceGoroutine(t *testing.T) {
+	st := errors.Stack(errors.New("ooh"))
+	text := fmt.Sprintf("goroutine 1 [running]:\n%#v", st)
+
+	got, err := errors.ParseStackTrace(text)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%+v", st), fmt.Sprintf("%+v", got))
+}
+
+func TestParseStackTracePanic(t *testing.T) {
+	text := "panic: boom\n" +
+		"\n" +
+		"goroutine 1 [running]:\n" +
+		"main.(*server).handle(0xc000010000, {0x4b2f20, 0x5})\n" +
+		"\t/src/app/server.go:42 +0x1d\n" +
+		"main.main()\n" +
+		"\t/src/app/main.go:10 +0x25\n" +
+		"created by main.start in goroutine 1\n" +
+		"\t/src/app/main.go:20 +0x3f\n" +
+		"exit status 2\n"
+
+	got, err := errors.ParseStackTrace(text)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	tests := []struct {
+		file, name string
+		line       int
+	}{
+		{"/src/app/server.go", "main.(*server).handle", 42},
+		{"/src/app/main.go", "main.main", 10},
+		{"/src/app/main.go", "main.start", 20},
+	}
+	for i, tt := range tests {
+		file, line, name := got[i].FuncInfo()
+		require.Equal(t, tt.file, file)
+		require.Equal(t, tt.line, line)
+		require.Equal(t, tt.name, name)
+	}
+
+	_, err = errors.ParseStackTrace("just a message")
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// pooledStacks is non-zero, if scratch buffers of stack capture are pooled.
+var pooledStacks int32
+
+var pcsPool = sync.Pool{New: func() interface{} { return new([]uintptr) }}
+
+// SetStackPooling enables or disables pooling of scratch buffers, which
+// receive program counters during stack capture. Buffers of default depth
+// (32) are always allocated on goroutine stack, so pooling only matters with
+// greater depths (see SetDefaultDepth), where each error would otherwise
+// allocate temporary buffer of depth size.
+func SetStackPooling(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&pooledStacks, v)
+}
+
+// getPCs returns scratch buffer of depth size. If pooling is disabled, it
+// returns nil, so caller must allocate buffer by itself.
+func getPCs(depth int) *[]uintptr {
+	if atomic.LoadInt32(&pooledStacks) == 0 {
+		return nil
+	}
+	p := pcsPool.Get().(*[]uintptr)
+	if cap(*p) < depth {
+		*p = make([]uintptr, depth)
+	}
+	*p = (*p)[:depth]
+	return p
+}
+
+func putPCs(p *[]uintptr) {
+	if p != nil {
+		pcsPool.Put(p)
+	}
+}
+
+// stackArenaSize is number of frames in each arena chunk, 0 if arena mode is
+// disabled.
+var stackArenaSize int32
+
+// stackArena is a chunk of frames, from which stack traces are carved.
+type stackArena struct{ free StackTrace }
+
+var arenaPool sync.Pool
+
+// SetStackArena enables arena mode of stack capture: instead of allocating
+// each stack trace separately, stack traces are carved from shared chunks of
+// size frames, so thousands of errors cost only a few allocations. size <= 0
+// disables arena mode.
+//
+// Note that chunk is kept in memory while at least one stack trace, carved
+// from it, is alive, so arena mode suits high rates of short-living errors
+// (e.g. logged and dropped in proxies), but not errors stored for a long
+// time. Stack traces longer than quarter of chunk are allocated separately.
+func SetStackArena(size int) {
+	if size < 0 {
+		size = 0
+	}
+	atomic.StoreInt32(&stackArenaSize, int32(size))
+}
+
+// allocStack returns exactly sized stack trace of n frames.
+func allocStack(n int) StackTrace {
+	size := int(atomic.LoadInt32(&stackArenaSize))
+	if n == 0 || n > size/4 {
+		return make(StackTrace, n)
+	}
+
+	arena, _ := arenaPool.Get().(*stackArena)
+	if arena == nil {
+		arena = new(stackArena)
+	}
+	if len(arena.free) < n {
+		arena.free = make(StackTrace, size)
+	}
+	// capacity is limited, so appending to stack never overwrites neighbours
+	stack := arena.free[:n:n]
+	arena.free = arena.free[n:]
+	arenaPool.Put(arena)
+
+	return stack
+}
@@ -0,0 +1,54 @@
+package errors_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetStackPooling(t *testing.T) {
+	defer errors.SetDefaultDepth(0)
+	defer errors.SetStackPooling(false)
+	errors.SetDefaultDepth(64)
+
+	want := errors.Stack(ownErrors(0, 100))
+	require.Len(t, want, 64)
+
+	errors.SetStackPooling(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				got := errors.Stack(ownErrors(0, 100))
+				require.Len(t, got, 64)
+				require.Equal(t, want[1:], got[1:])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetStackArena(t *testing.T) {
+	defer errors.SetStackArena(0)
+	errors.SetStackArena(256)
+
+	first := errors.Stack(ownErrors(0, 5))
+	second := errors.Stack(ownErrors(0, 10))
+	require.Equal(t, len(first), cap(first))
+
+	want := append(errors.StackTrace(nil), second...)
+	_ = append(first, first...)
+	require.Equal(t, want, second)
+
+	require.Equal(t, errors.PkgName+".ownErrors", funcName(first[0]))
+	require.Equal(t, errors.PkgName+".TestSetStackArena", funcName(first[len(first)-3]))
+
+	errors.SetStackArena(0)
+	require.NotEmpty(t, errors.Stack(errors.New("error")))
+}
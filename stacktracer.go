@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"reflect"
+	"sync"
+)
+
+// StackTracer is implemented by errors, which carry stack trace. Error types
+// of other packages may implement it to participate in Stack, Stacks,
+// FormatChain, ToJSON and other functions of this package, which extract
+// stack traces.
+//
+// Errors of github.com/pkg/errors (and other packages, which declare
+// StackTrace method returning slice of program counters in the same way) are
+// supported as well, without implementing StackTracer.
+type StackTracer interface {
+	StackTrace() StackTrace
+}
+
+// stackOf returns stack trace carried by err itself, not by its causes.
+func stackOf(err error) (StackTrace, bool) {
+	switch e := err.(type) {
+	case interface{ stackTrace() StackTrace }:
+		return e.stackTrace(), true
+	case StackTracer:
+		return e.StackTrace(), true
+	case nil:
+		return nil, false
+	}
+	return foreignStack(err)
+}
+
+// foreignMethods caches index of pkg/errors style StackTrace method of error
+// types, -1 if type has no such method.
+var foreignMethods sync.Map // map[reflect.Type]int
+
+// foreignStack returns stack trace of err, which has StackTrace method,
+// returning slice of uintptr based frames, e.g. stack trace of
+// github.com/pkg/errors.
+func foreignStack(err error) (StackTrace, bool) {
+	v := reflect.ValueOf(err)
+	t := v.Type()
+
+	index, ok := foreignMethods.Load(t)
+	if !ok {
+		index = -1
+		if m, ok := t.MethodByName("StackTrace"); ok {
+			mt := m.Type // receiver is the first argument
+			if mt.NumIn() == 1 && mt.NumOut() == 1 &&
+				mt.Out(0).Kind() == reflect.Slice && mt.Out(0).Elem().Kind() == reflect.Uintptr {
+				index = m.Index
+			}
+		}
+		foreignMethods.Store(t, index)
+	}
+	if index.(int) < 0 {
+		return nil, false
+	}
+
+	frames := v.Method(index.(int)).Call(nil)[0]
+	if frames.IsNil() {
+		return nil, true
+	}
+	res := make(StackTrace, frames.Len())
+	for i := range res {
+		res[i] = Frame(frames.Index(i).Uint())
+	}
+	// adapters of errors of this package (e.g. pkg/errors compatibility
+	// layer) expose stack trace of their cause, which must not be counted
+	// twice
+	if res.Equal(Stack(Unwrap(err))) {
+		return nil, false
+	}
+	return res, true
+}
@@ -0,0 +1,44 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+type tracedError struct {
+	stack errors.StackTrace
+}
+
+func (e *tracedError) Error() string                 { return "traced" }
+func (e *tracedError) StackTrace() errors.StackTrace { return e.stack }
+
+func TestStackTracer(t *testing.T) {
+	err := &tracedError{stack: errors.Callers(0)}
+	var _ errors.StackTracer = err
+
+	wrapped := errors.Wrap(err, "wrapped")
+	require.Equal(t, err.stack, errors.Stack(wrapped))
+	require.Equal(t, []errors.StackTrace{err.stack}, errors.Stacks(wrapped))
+	require.Equal(t, errors.PkgName+".TestStackTracer", funcName(errors.Stack(wrapped)[0]))
+}
+
+func TestStackPkgErrors(t *testing.T) {
+	err := pkgerrors.New("pkg error")
+
+	stack := errors.Stack(err)
+	require.Len(t, stack, len(err.(interface{ StackTrace() pkgerrors.StackTrace }).StackTrace()))
+	require.Equal(t, errors.PkgName+".TestStackPkgErrors", funcName(stack[0]))
+
+	wrapped := errors.Wrap(err, "wrapped")
+	require.Equal(t, stack, errors.Stack(wrapped))
+	require.Contains(t, errors.FormatChain(wrapped), "*errors.fundamental: pkg error\n    "+errors.PkgName+".TestStackPkgErrors\n")
+	require.Contains(t, fmt.Sprintf("%+v", stack), "TestStackPkgErrors")
+
+	// pkg/errors records new stack on each wrap
+	require.Len(t, errors.Stacks(pkgerrors.WithStack(err)), 2)
+}
@@ -32,3 +32,14 @@ func As(err error, target interface{}) bool { return stderrors.As(err, target) }
 // type contains an Unwrap method returning error.
 // Otherwise, Unwrap returns nil.
 func Unwrap(err error) error { return stderrors.Unwrap(err) }
+
+// IsAny reports whether any error in err's chain matches any of targets (see
+// Is).
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if stderrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
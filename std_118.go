@@ -0,0 +1,53 @@
+//go:build go1.18
+
+package errors
+
+// AsAll finds all errors in err's tree (including errors aggregated by
+// multi-errors), which match type T, in depth-first order. Unlike As, it
+// doesn't stop on the first match, which is useful, when multiple wrappers
+// in the chain carry the same typed payload.
+//
+// An error matches T if its concrete value is assignable to T, or if it has
+// a method As(interface{}) bool such that As(*T) returns true.
+func AsAll[T any](err error) []T {
+	var res []T
+	walkTree(err, func(e error) {
+		if t, ok := e.(T); ok {
+			res = append(res, t)
+			return
+		}
+		if a, ok := e.(interface{ As(interface{}) bool }); ok {
+			var t T
+			if a.As(&t) {
+				res = append(res, t)
+			}
+		}
+	})
+	return res
+}
+
+// AsType finds the first error in err's chain that matches type T, and if
+// so, returns it. It's a generic version of As, which doesn't require target
+// variable:
+//
+//	if e, ok := errors.AsType[*MyErr](err); ok {
+//		// handle e
+//	}
+func AsType[T any](err error) (T, bool) {
+	var target T
+	if err == nil {
+		return target, false
+	}
+	ok := As(err, &target)
+	return target, ok
+}
+
+// MustAsType works like AsType, but panics, if err's chain doesn't contain
+// error of type T. It's intended for tests.
+func MustAsType[T any](err error) T {
+	target, ok := AsType[T](err)
+	if !ok {
+		panic(Errorf("error %q doesn't contain %T in its chain", err, target))
+	}
+	return target
+}
@@ -3,6 +3,7 @@ package errors_test
 import (
 	stderrors "errors"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 
@@ -172,3 +173,36 @@ func TestUnwrap(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAny(t *testing.T) {
+	err := errors.Wrap(io.EOF, "read")
+
+	require.True(t, errors.IsAny(err, io.ErrUnexpectedEOF, io.EOF))
+	require.False(t, errors.IsAny(err, io.ErrUnexpectedEOF))
+	require.False(t, errors.IsAny(err))
+}
+
+func TestAsAll(t *testing.T) {
+	err := errors.Wrap(customErr{msg: "outer"}, "read")
+	err = fmt.Errorf("wrap: %w", errors.Join(err, customErr{msg: "inner"}))
+
+	require.Equal(t, []customErr{{msg: "outer"}, {msg: "inner"}}, errors.AsAll[customErr](err))
+	require.Nil(t, errors.AsAll[customErr](io.EOF))
+	require.Len(t, errors.AsAll[interface{ Unwrap() error }](err), 3)
+}
+
+func TestAsType(t *testing.T) {
+	err := errors.Wrap(customErr{msg: "test"}, "read")
+
+	got, ok := errors.AsType[customErr](err)
+	require.True(t, ok)
+	require.Equal(t, customErr{msg: "test"}, got)
+
+	_, ok = errors.AsType[customErr](io.EOF)
+	require.False(t, ok)
+	_, ok = errors.AsType[customErr](nil)
+	require.False(t, ok)
+
+	require.Equal(t, customErr{msg: "test"}, errors.MustAsType[customErr](err))
+	require.Panics(t, func() { errors.MustAsType[customErr](io.EOF) })
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const symbolCacheShards = 16
+
+// symbolCache is a concurrent cache of resolved frames. Program counters of
+// current binary never change their meaning, so cached entries are never
+// invalidated: when shard becomes full, it is simply dropped and filled
+// again with frames, which are actually used.
+type symbolCache struct {
+	max    int // per shard
+	shards [symbolCacheShards]symbolCacheShard
+}
+
+type symbolCacheShard struct {
+	sync.RWMutex
+	frames map[Frame]frameInfo
+}
+
+// symbols holds *symbolCache, nil if cache is disabled.
+var symbols atomic.Value
+
+// SetSymbolCache enables cache of resolved frames of size entries, so same
+// frames are not symbolized again each time error is formatted or logged.
+// size <= 0 disables cache. Each call drops all cached entries.
+func SetSymbolCache(size int) {
+	if size <= 0 {
+		symbols.Store((*symbolCache)(nil))
+		return
+	}
+	max := (size + symbolCacheShards - 1) / symbolCacheShards
+	symbols.Store(&symbolCache{max: max})
+}
+
+func loadSymbolCache() *symbolCache {
+	c, _ := symbols.Load().(*symbolCache)
+	return c
+}
+
+func (c *symbolCache) shard(f Frame) *symbolCacheShard {
+	// low bits of pc are aligned and mostly identical, so mix them a bit
+	return &c.shards[(uintptr(f)^uintptr(f)>>7)%symbolCacheShards]
+}
+
+func (c *symbolCache) get(f Frame) (frameInfo, bool) {
+	s := c.shard(f)
+	s.RLock()
+	info, ok := s.frames[f]
+	s.RUnlock()
+	return info, ok
+}
+
+func (c *symbolCache) put(f Frame, info frameInfo) {
+	s := c.shard(f)
+	s.Lock()
+	if s.frames == nil || len(s.frames) >= c.max {
+		s.frames = make(map[Frame]frameInfo, c.max)
+	}
+	s.frames[f] = info
+	s.Unlock()
+}
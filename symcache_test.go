@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestSetSymbolCache(t *testing.T) {
+	defer errors.SetSymbolCache(0)
+
+	stack := errors.Stack(ownErrors(0, 20))
+	want := fmt.Sprintf("%+v", stack)
+
+	for _, size := range []int{1, 16, 1024} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			errors.SetSymbolCache(size)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for j := 0; j < 10; j++ {
+						require.Equal(t, want, fmt.Sprintf("%+v", stack))
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+
+	f := errors.Frame(0)
+	file, line, name := f.FuncInfo()
+	require.Equal(t, "unknown", file)
+	require.Zero(t, line)
+	require.Equal(t, "unknown", name)
+}
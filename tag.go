@@ -0,0 +1,100 @@
+package errors
+
+import "fmt"
+
+// Tag is a lightweight marker, which can be attached to errors with WithTag
+// and checked with Is. It's a cheaper alternative to defining sentinel
+// errors for cross-cutting concerns:
+//
+//	var UserFacing = errors.NewTag("user-facing")
+//
+//	err = errors.WithTag(err, UserFacing)
+//	...
+//	if errors.Is(err, UserFacing) {
+//		...
+//	}
+//
+// Each tag, returned by NewTag, is unique, even if names are the same. Zero
+// Tag is invalid.
+type Tag struct {
+	info *tagInfo
+}
+
+type tagInfo struct {
+	name string
+}
+
+// NewTag returns a new unique tag with name.
+func NewTag(name string) Tag { return Tag{info: &tagInfo{name: name}} }
+
+// Name returns name of the tag.
+func (t Tag) Name() string {
+	if t.info == nil {
+		return ""
+	}
+	return t.info.name
+}
+
+// Error implements error, so tag can be passed to Is as a target.
+func (t Tag) Error() string  { return "tag " + t.Name() }
+func (t Tag) String() string { return t.Name() }
+
+type withTags struct {
+	cause error
+	tags  []Tag
+}
+
+// WithTag marks err with tags, so Is(err, tag) reports true for each of
+// them.
+// If err is nil, WithTag returns nil.
+func WithTag(err error, tags ...Tag) error {
+	if err == nil {
+		return nil
+	}
+	return &withTags{cause: err, tags: tags}
+}
+
+func (w *withTags) Error() string   { return w.cause.Error() }
+func (w *withTags) Unwrap() error   { return w.cause }
+func (w *withTags) message() string { return "" }
+
+// Is reports whether target is one of tags of w.
+func (w *withTags) Is(target error) bool {
+	t, ok := target.(Tag)
+	if !ok {
+		return false
+	}
+	for _, tag := range w.tags {
+		if tag == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *withTags) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withTags) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Tags returns all tags in err's tree (see WithTag) in depth-first order,
+// from the outermost error to the innermost one. Each tag is listed once.
+// If there are no tags, Tags returns nil.
+func Tags(err error) []Tag {
+	var res []Tag
+	walkTree(err, func(e error) {
+		w, ok := e.(*withTags)
+		if !ok {
+			return
+		}
+	next:
+		for _, tag := range w.tags {
+			for _, seen := range res {
+				if seen == tag {
+					continue next
+				}
+			}
+			res = append(res, tag)
+		}
+	})
+	return res
+}
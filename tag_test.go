@@ -0,0 +1,40 @@
+package errors_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestTag(t *testing.T) {
+	userFacing := errors.NewTag("user-facing")
+	billable := errors.NewTag("billable")
+	other := errors.NewTag("user-facing")
+
+	require.Nil(t, errors.WithTag(nil, userFacing))
+	require.Nil(t, errors.Tags(io.EOF))
+
+	err := errors.Wrap(errors.WithTag(io.EOF, userFacing), "charge")
+	err = errors.WithTag(err, billable, userFacing)
+
+	require.ErrorIs(t, err, userFacing)
+	require.ErrorIs(t, err, billable)
+	require.ErrorIs(t, err, io.EOF)
+	require.NotErrorIs(t, err, other, "tags with the same name are different")
+	require.Equal(t, "charge: EOF", err.Error())
+
+	require.Equal(t, []errors.Tag{billable, userFacing}, errors.Tags(err))
+	require.Equal(t, "user-facing", userFacing.Name())
+	require.Equal(t, "user-facing", userFacing.String())
+	require.Empty(t, errors.Tag{}.Name())
+}
+
+func TestTagsJoined(t *testing.T) {
+	a, b := errors.NewTag("a"), errors.NewTag("b")
+	err := errors.Join(errors.WithTag(io.EOF, a), errors.WithTag(io.ErrUnexpectedEOF, b))
+	require.ErrorIs(t, err, b)
+	require.Equal(t, []errors.Tag{a, b}, errors.Tags(err))
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+)
+
+type templated struct {
+	format string
+	msg    string
+	args   []Field
+	stack  StackTrace
+}
+
+// Arg returns named argument of NewT message template.
+func Arg(name string, value interface{}) Field { return Field{Key: name, Value: value} }
+
+// NewT returns an error with message, formatted according to a format
+// specifier with values of args, and records the stack trace. Unlike Errorf,
+// it keeps format and named arguments, so they can be extracted later with
+// Args (e.g. to pass to structured logs or to translate message):
+//
+//	err := errors.NewT("user %s not found", errors.Arg("user", id))
+//	errors.Args(err) // [{user 42}]
+//
+// Arguments are also available in Fields.
+func NewT(format string, args ...Field) error {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return created(&templated{
+		format: format,
+		msg:    fmt.Sprintf(format, values...),
+		args:   args,
+		stack:  callers(1),
+	})
+}
+
+func (t *templated) Error() string          { return t.msg }
+func (t *templated) stackTrace() StackTrace { return t.stack }
+func (t *templated) message() string        { return t.msg }
+func (t *templated) fields() []Field        { return t.args }
+
+func (t *templated) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && len(t.stack) > 0 {
+			io.WriteString(s, t.msg+"\n")
+			t.stack.Format(s, verb)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, t.msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", t.msg)
+	}
+}
+
+func (t *templated) MarshalJSON() ([]byte, error) { return ToJSON(t) }
+
+// Args returns named arguments of the outermost error in err's tree, created
+// with NewT. If there is no such error, Args returns nil.
+func Args(err error) []Field {
+	var res []Field
+	walkTree(err, func(e error) {
+		if t, ok := e.(*templated); ok && res == nil {
+			res = t.args
+		}
+	})
+	return res
+}
@@ -0,0 +1,42 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestNewT(t *testing.T) {
+	err := errors.NewT("user %s not found in %v", errors.Arg("user", "alice"), errors.Arg("shard", 3))
+
+	require.EqualError(t, err, "user alice not found in 3")
+	require.Equal(t, []errors.Field{{Key: "user", Value: "alice"}, {Key: "shard", Value: 3}}, errors.Args(err))
+	require.Equal(t, map[string]interface{}{"user": "alice", "shard": 3}, errors.Fields(err))
+	require.Regexp(t, `^user alice not found in 3\n.+TestNewT\n`, fmt.Sprintf("%+v", err))
+}
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []errors.Field
+	}{
+		{"nil", nil, nil},
+		{"no template", io.EOF, nil},
+		{"wrapped", errors.Wrap(errors.NewT("id %d", errors.Arg("id", 1)), "get"), []errors.Field{{Key: "id", Value: 1}}},
+		{"outermost", errors.Join(
+			errors.NewT("id %d", errors.Arg("id", 1)),
+			errors.NewT("id %d", errors.Arg("id", 2)),
+		), []errors.Field{{Key: "id", Value: 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, errors.Args(tt.err))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// timestamps is non-zero, if constructors annotate errors with creation
+// time, see SetTimestamps.
+var timestamps int32
+
+// SetTimestamps enables or disables automatic annotation of errors with time
+// of their creation: while enabled, errors created by New, Errorf, Wrap,
+// WithStack and other constructors, which record stack trace, are annotated
+// as with WithTime, unless they already have time in their chain. It costs
+// an additional allocation per error, so it's disabled by default.
+func SetTimestamps(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&timestamps, v)
+}
+
+type withTime struct {
+	cause error
+	time  time.Time
+}
+
+// WithTime annotates err with current time, which can be extracted later
+// with Time.
+// If err is nil, WithTime returns nil.
+func WithTime(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withTime{cause: err, time: time.Now()}
+}
+
+func (w *withTime) Error() string   { return w.cause.Error() }
+func (w *withTime) Unwrap() error   { return w.cause }
+func (w *withTime) message() string { return "" }
+
+func (w *withTime) Format(s fmt.State, verb rune) { formatTransparent(s, verb, w.cause) }
+
+func (w *withTime) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+// Time returns the earliest time, err was annotated with (see WithTime and
+// SetTimestamps): time of the innermost annotation in err's chain, which is
+// usually the moment, when the underlying failure occurred. If there is no
+// such annotation, Time returns false.
+func Time(err error) (time.Time, bool) {
+	var res time.Time
+	found := false
+	for ; err != nil; err = Unwrap(err) {
+		if w, ok := err.(*withTime); ok {
+			res, found = w.time, true
+		}
+	}
+	return res, found
+}
@@ -0,0 +1,56 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestWithTime(t *testing.T) {
+	require.Nil(t, errors.WithTime(nil))
+
+	_, ok := errors.Time(io.EOF)
+	require.False(t, ok)
+
+	before := time.Now()
+	inner := errors.WithTime(io.EOF)
+	at, ok := errors.Time(inner)
+	require.True(t, ok)
+	require.False(t, at.Before(before))
+
+	time.Sleep(time.Millisecond)
+	err := errors.WithTime(errors.Wrap(inner, "retry"))
+	got, ok := errors.Time(err)
+	require.True(t, ok)
+	require.Equal(t, at, got, "innermost time must be reported")
+
+	require.Equal(t, "retry: EOF", err.Error())
+	require.Equal(t, "retry: EOF", fmt.Sprintf("%v", err))
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, io.EOF, errors.Cause(err))
+}
+
+func TestSetTimestamps(t *testing.T) {
+	errors.SetTimestamps(true)
+	defer errors.SetTimestamps(false)
+
+	err := errors.New("boom")
+	at, ok := errors.Time(err)
+	require.True(t, ok)
+	require.NotNil(t, errors.Stack(err))
+	require.Equal(t, "boom", err.Error())
+
+	wrapped := errors.Wrap(err, "outer")
+	got, ok := errors.Time(wrapped)
+	require.True(t, ok)
+	require.Equal(t, at, got)
+
+	errors.SetTimestamps(false)
+	_, ok = errors.Time(errors.New("boom"))
+	require.False(t, ok)
+}
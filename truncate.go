@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Limits are size limits of error output, see Truncate.
+type Limits struct {
+	// Message is maximum length of error message in bytes (without
+	// truncation marker). Zero means no limit.
+	Message int
+	// Frames is maximum number of frames of stack trace. Zero means no
+	// limit.
+	Frames int
+}
+
+type truncated struct {
+	cause  error
+	limits Limits
+	// at is length of message, after which it was cut, -1 if message fits
+	// into limit.
+	at  int
+	msg string
+}
+
+// Truncate returns an error, which caps output of err by limits: its Error()
+// is cut to limits.Message bytes, followed by marker with original length,
+// and its stack trace is cut to limits.Frames frames. It's a guard for
+// errors, which may accumulate huge payloads (e.g. SQL queries or response
+// bodies), before they reach logs. Use TruncatedAt to check, whether message
+// was actually cut.
+//
+// Extended format (%+v), JSON representation, slog value and reports (see
+// Explain, FormatChain and RenderMarkdown) of returned error contain
+// truncated message and truncated stack trace only, without details of each
+// error in the chain, since they may repeat huge messages. Is, As and Unwrap
+// see err as is.
+// If err is nil, Truncate returns nil.
+func Truncate(err error, limits Limits) error {
+	if err == nil {
+		return nil
+	}
+	t := &truncated{cause: err, limits: limits, at: -1}
+	t.msg = err.Error()
+	if limits.Message > 0 && len(t.msg) > limits.Message {
+		at := limits.Message
+		for at > 0 && !utf8.RuneStart(t.msg[at]) {
+			at--
+		}
+		t.msg = t.msg[:at] + "... (truncated, " + strconv.Itoa(len(t.msg)) + " bytes total)"
+		t.at = at
+	}
+	return t
+}
+
+// TruncatedAt returns length of error message, after which it was cut by the
+// outermost Truncate in err's chain. If message wasn't cut, TruncatedAt
+// returns false.
+func TruncatedAt(err error) (int, bool) {
+	for ; err != nil; err = Unwrap(err) {
+		if t, ok := err.(*truncated); ok {
+			return t.at, t.at >= 0
+		}
+	}
+	return 0, false
+}
+
+// IsTruncated reports whether err itself (not any error in its chain) was
+// returned by Truncate. Code, which walks the chain with Unwrap to log
+// messages of each error (e.g. adapters of logging libraries), must stop at
+// such error and use its message, since errors behind it carry messages
+// without limits.
+func IsTruncated(err error) bool {
+	_, ok := err.(*truncated)
+	return ok
+}
+
+func (t *truncated) Error() string   { return t.msg }
+func (t *truncated) Unwrap() error   { return t.cause }
+func (t *truncated) message() string { return t.msg }
+
+func (t *truncated) stackTrace() StackTrace {
+	stack := Stack(t.cause)
+	if t.limits.Frames > 0 && len(stack) > t.limits.Frames {
+		stack = stack[:t.limits.Frames:t.limits.Frames]
+	}
+	return stack
+}
+
+func (t *truncated) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, t.msg)
+			if stack := t.stackTrace(); len(stack) > 0 {
+				io.WriteString(s, "\n")
+				stack.Format(s, verb)
+				if len(stack) < len(Stack(t.cause)) {
+					io.WriteString(s, "...additional frames elided...\n")
+				}
+			}
+			formatFooter(s, t.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, t.msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", t.msg)
+	}
+}
+
+func (t *truncated) MarshalJSON() ([]byte, error) { return ToJSON(t) }
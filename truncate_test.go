@@ -0,0 +1,86 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestTruncate(t *testing.T) {
+	require.Nil(t, errors.Truncate(nil, errors.Limits{Message: 10}))
+
+	payload := strings.Repeat("x", 1000)
+	err := errors.Truncate(errors.Wrap(io.EOF, "query "+payload), errors.Limits{Message: 10, Frames: 1})
+
+	require.EqualError(t, err, "query xxxx... (truncated, 1011 bytes total)")
+	require.ErrorIs(t, err, io.EOF)
+	at, ok := errors.TruncatedAt(errors.Wrap(err, "wrapped"))
+	require.True(t, ok)
+	require.Equal(t, 10, at)
+
+	require.Len(t, errors.Stack(err), 1)
+	require.Equal(t, errors.PkgName+".TestTruncate", funcName(errors.Stack(err)[0]))
+	requireMultilineRegexp(t, "^query xxxx... \\(truncated, 1011 bytes total\\)$\n"+
+		"^"+errors.PkgName+".TestTruncate$\n"+
+		"^\t.+/truncate_test.go:\\d+$\n"+
+		"^...additional frames elided...$\n"+
+		"^$", fmt.Sprintf("%+v", err))
+
+	data, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+	require.Less(t, len(data), 200)
+}
+
+func TestIsTruncated(t *testing.T) {
+	err := errors.Truncate(io.EOF, errors.Limits{Message: 10})
+	require.True(t, errors.IsTruncated(err))
+	require.False(t, errors.IsTruncated(errors.Wrap(err, "read")))
+	require.False(t, errors.IsTruncated(io.EOF))
+	require.False(t, errors.IsTruncated(nil))
+}
+
+func TestTruncateFits(t *testing.T) {
+	err := errors.Truncate(errors.New("short"), errors.Limits{Message: 10})
+	require.EqualError(t, err, "short")
+	_, ok := errors.TruncatedAt(err)
+	require.False(t, ok)
+	require.Equal(t, errors.Stack(errors.Unwrap(err)), errors.Stack(err))
+
+	_, ok = errors.TruncatedAt(io.EOF)
+	require.False(t, ok)
+}
+
+func TestTruncateRunes(t *testing.T) {
+	err := errors.Truncate(errors.NoStack("привет"), errors.Limits{Message: 3})
+	require.EqualError(t, err, "п... (truncated, 12 bytes total)")
+	at, _ := errors.TruncatedAt(err)
+	require.Equal(t, 2, at)
+}
+
+func TestTruncateWalkers(t *testing.T) {
+	payload := strings.Repeat("x", 200)
+	err := errors.Wrap(errors.Truncate(errors.Wrap(errors.New(payload), "query"), errors.Limits{Message: 10}), "handle")
+
+	tests := []struct {
+		name string
+		out  string
+	}{
+		{"Explain", errors.Explain(err)},
+		{"FormatChain", errors.FormatChain(err)},
+		{"RenderMarkdown", errors.RenderMarkdown(err)},
+		{"RenderHTML", errors.RenderHTML(err)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, tt.out, "query: xxx... (truncated, 207 bytes total)")
+			require.NotContains(t, tt.out, payload)
+		})
+	}
+}
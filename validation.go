@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Violation is a single failed constraint of validated input.
+type Violation struct {
+	// Field is a path to invalid value, e.g. "user.email". Empty field
+	// means the input as a whole.
+	Field string `json:"field,omitempty"`
+	// Message describes violated constraint, e.g. "must be an email".
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return v.Field + ": " + v.Message
+}
+
+// ValidationError is an error, which lists all violations of validated
+// input. It's built with Invalid and And:
+//
+//	err := errors.Invalid("user.email", "must be an email").
+//		And("user.age", "must be positive")
+//
+// and renders compactly as "user.email: must be an email; user.age: must be
+// positive". ValidationError is classified as KindInvalid.
+type ValidationError struct {
+	violations []Violation
+	stack      StackTrace
+	// annotated is the error itself, annotated with timestamp, goroutine
+	// labels or build info, if they are enabled.
+	annotated error
+}
+
+// Invalid returns ValidationError with single violation of field. Invalid
+// also records the stack trace at the point it was called.
+//
+// Timestamp, goroutine labels and build info (see SetTimestamps,
+// SetGoroutineLabels and SetBuildInfo) are attached to the error, returned
+// by Err, since *ValidationError itself can't be wrapped in place.
+func Invalid(field, message string) *ValidationError {
+	err := &ValidationError{
+		violations: []Violation{{Field: field, Message: message}},
+		stack:      callers(1),
+	}
+	err.annotated = created(err)
+	return err
+}
+
+// And adds violation of field to e and returns e.
+func (e *ValidationError) And(field, message string) *ValidationError {
+	e.violations = append(e.violations, Violation{Field: field, Message: message})
+	return e
+}
+
+// Violations returns violations of e.
+func (e *ValidationError) Violations() []Violation { return e.violations }
+
+// Err returns e as an error with its annotations (see Invalid), or nil, if e
+// has no violations. It's useful, when violations are collected
+// conditionally. Nil *ValidationError has no violations.
+func (e *ValidationError) Err() error {
+	if e == nil || len(e.violations) == 0 {
+		return nil
+	}
+	if e.annotated != nil {
+		return e.annotated
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.violations))
+	for i, v := range e.violations {
+		msgs[i] = v.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) stackTrace() StackTrace { return e.stack }
+func (e *ValidationError) message() string        { return e.Error() }
+func (e *ValidationError) errorKind() Kind        { return KindInvalid }
+func (e *ValidationError) HTTPStatus() int        { return KindInvalid.HTTPStatus() }
+
+func (e *ValidationError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') && len(e.stack) > 0 {
+			io.WriteString(s, e.Error()+"\n")
+			e.stack.Format(s, verb)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+func (e *ValidationError) MarshalJSON() ([]byte, error) { return ToJSON(e) }
+
+// Violations returns violations of all ValidationErrors in err's tree, in
+// depth-first order. If there are none, Violations returns nil.
+func Violations(err error) []Violation {
+	var res []Violation
+	walkTree(err, func(e error) {
+		if v, ok := e.(*ValidationError); ok {
+			res = append(res, v.violations...)
+		}
+	})
+	return res
+}
@@ -0,0 +1,66 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestInvalid(t *testing.T) {
+	err := errors.Invalid("user.email", "must be an email").
+		And("user.age", "must be positive").
+		And("", "request is empty")
+
+	require.EqualError(t, err, "user.email: must be an email; user.age: must be positive; request is empty")
+	require.Equal(t, []errors.Violation{
+		{Field: "user.email", Message: "must be an email"},
+		{Field: "user.age", Message: "must be positive"},
+		{Message: "request is empty"},
+	}, err.Violations())
+	require.Equal(t, errors.KindInvalid, errors.KindOf(err))
+	require.Equal(t, http.StatusBadRequest, errors.HTTPStatus(err))
+	require.Equal(t, errors.PkgName+".TestInvalid", funcName(errors.Stack(err)[0]))
+	require.Regexp(t, `^user.email: must be an email; user.age: must be positive; request is empty\n`+errors.PkgName+`.TestInvalid\n`, fmt.Sprintf("%+v", err))
+}
+
+func TestInvalidErr(t *testing.T) {
+	var v *errors.ValidationError
+	require.NoError(t, v.Err())
+
+	err := errors.Invalid("name", "is required").Err()
+	require.EqualError(t, err, "name: is required")
+}
+
+func TestInvalidAnnotated(t *testing.T) {
+	errors.SetTimestamps(true)
+	defer errors.SetTimestamps(false)
+
+	v := errors.Invalid("name", "is required")
+	err := v.And("age", "must be positive").Err()
+	require.EqualError(t, err, "name: is required; age: must be positive")
+	_, ok := errors.Time(err)
+	require.True(t, ok)
+
+	var target *errors.ValidationError
+	require.True(t, errors.As(err, &target))
+	require.Same(t, v, target)
+}
+
+func TestViolations(t *testing.T) {
+	require.Nil(t, errors.Violations(nil))
+	require.Nil(t, errors.Violations(errors.New("error")))
+
+	err := errors.Wrap(errors.Join(
+		errors.Invalid("user.email", "must be an email"),
+		errors.Invalid("order.id", "is required"),
+	), "create order")
+	require.Equal(t, []errors.Violation{
+		{Field: "user.email", Message: "must be an email"},
+		{Field: "order.id", Message: "is required"},
+	}, errors.Violations(err))
+	require.Equal(t, errors.KindInvalid, errors.KindOf(errors.Wrap(errors.Invalid("a", "b"), "wrapped")))
+}
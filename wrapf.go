@@ -0,0 +1,154 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// wrapError is an error created by Errorf with single %w verb, which message
+// doesn't end with message of its cause (otherwise Errorf creates the same
+// error as Wrap does).
+type wrapError struct {
+	msg   string
+	cause error
+	// at is a point, where error was created, if its cause already had a
+	// stack trace.
+	at Frame
+}
+
+// wrapErrors is an error created by Errorf with several %w verbs, or by
+// Wrapf with %w verbs.
+type wrapErrors struct {
+	msg    string
+	own    string
+	causes []error
+	// at is a point, where error was created, if its causes already had a
+	// stack trace.
+	at Frame
+}
+
+// errorf converts e, created by fmt.Errorf, into error of this package.
+// Formatting is done by callers, so vet checks their format strings.
+func errorf(e error, extraSkip uint) error {
+	causes := operandsOf(e)
+	switch {
+	case len(causes) == 1:
+		msg, cause := e.Error(), causes[0]
+		own := strings.TrimSuffix(msg, loadSeparator()+cause.Error())
+		if own != msg && atomic.LoadInt32(&ownMessages) == 0 {
+			return wrap(cause, own, 1+extraSkip)
+		}
+		w := &wrapError{msg: msg, cause: cause}
+		return wrapCauses(w, &w.at, 1+extraSkip)
+	case len(causes) > 1:
+		w := &wrapErrors{msg: e.Error(), own: e.Error(), causes: causes}
+		return wrapCauses(w, &w.at, 1+extraSkip)
+	}
+	return newFundamental(e.Error(), 1+extraSkip)
+}
+
+// operandsOf returns non-nil operands of %w verbs of e, created by
+// fmt.Errorf.
+func operandsOf(e error) []error {
+	var all []error
+	switch u := e.(type) {
+	case interface{ Unwrap() error }:
+		all = []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		all = u.Unwrap()
+	}
+	var res []error
+	for _, err := range all {
+		if err != nil {
+			res = append(res, err)
+		}
+	}
+	return res
+}
+
+// wrapf annotates err with e, created by fmt.Errorf, see errorf.
+func wrapf(err, e error, extraSkip uint) error {
+	operands := operandsOf(e)
+	if len(operands) == 0 {
+		return wrap(err, e.Error(), 1+extraSkip)
+	}
+	w := &wrapErrors{
+		msg:    joinMessage(e.Error(), err),
+		own:    e.Error(),
+		causes: append([]error{err}, operands...),
+	}
+	return wrapCauses(w, &w.at, 1+extraSkip)
+}
+
+// wrapCauses records stack trace of err the same way as Wrap does: if
+// causes of err already have a stack trace, only frame of the caller is
+// stored in at.
+func wrapCauses(err error, at *Frame, extraSkip uint) error {
+	if Stack(err) != nil {
+		if stack := callersDepth(1+extraSkip, 1); len(stack) > 0 {
+			*at = stack[0]
+		}
+		return created(err)
+	}
+	return created(&withStack{err, callers(1 + extraSkip)})
+}
+
+func (w *wrapError) Error() string    { return w.msg }
+func (w *wrapError) Unwrap() error    { return w.cause }
+func (w *wrapError) message() string  { return trimCause(w.msg, w.cause) }
+func (w *wrapError) wrappedAt() Frame { return w.at }
+
+func (w *wrapError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			formatWrapped(s, w.msg+"\n", w.message(), w.cause, w.at)
+			formatFooter(s, w.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", w.msg)
+	}
+}
+
+func (w *wrapError) MarshalJSON() ([]byte, error) { return ToJSON(w) }
+
+func (w *wrapErrors) Error() string    { return w.msg }
+func (w *wrapErrors) Unwrap() []error  { return w.causes }
+func (w *wrapErrors) message() string  { return w.own }
+func (w *wrapErrors) wrappedAt() Frame { return w.at }
+
+func (w *wrapErrors) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, w.msg)
+			for i, err := range w.causes {
+				var buf bytes.Buffer
+				formatDetailedVerb(&buf, err, detailVerb(s))
+				child := strings.TrimRight(buf.String(), "\n")
+				io.WriteString(s, "\n  ["+strconv.Itoa(i+1)+"] ")
+				io.WriteString(s, strings.ReplaceAll(child, "\n", "\n      "))
+			}
+			if w.at != 0 {
+				fmt.Fprintf(s, "\n%+v: %s\n", w.at, w.own)
+			}
+			formatFooter(s, w)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.msg)
+	case 'q':
+		fmt.Fprintf(s, "%q", w.msg)
+	}
+}
+
+func (w *wrapErrors) MarshalJSON() ([]byte, error) { return ToJSON(w) }
@@ -0,0 +1,105 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/quenbyako/errors"
+)
+
+func TestErrorfWrapsLikeWrap(t *testing.T) {
+	// cause without stack trace: the whole stack is recorded
+	err := errors.Errorf("parse %s: %w", "config.yaml", io.EOF)
+	require.EqualError(t, err, "parse config.yaml: EOF")
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, io.EOF, errors.Cause(err))
+	require.Equal(t, errors.PkgName+".TestErrorfWrapsLikeWrap", funcName(errors.Stack(err)[0]))
+	require.True(t, errors.EqualChains(errors.Wrapf(io.EOF, "parse %s", "config.yaml"), err))
+
+	// cause with stack trace: only the wrap point is recorded
+	inner := errors.New("boom")
+	err = errors.Errorf("parse: %w", inner)
+	require.Equal(t, errors.Stack(inner), errors.Stack(err))
+	require.Len(t, errors.Stacks(err), 1)
+	require.Regexp(t, `(?s)^parse: boom\n.*\n`+regexp.QuoteMeta(errors.PkgName)+`\.TestErrorfWrapsLikeWrap\n\t.+wrapf_test\.go:\d+: parse\n$`, fmt.Sprintf("%+v", err))
+	require.Equal(t, "1. parse; 2. boom", errors.Explain(err))
+}
+
+func TestErrorfCauseInTheMiddle(t *testing.T) {
+	pathErr := &os.PathError{Op: "open", Path: "a.txt", Err: os.ErrNotExist}
+	err := errors.Errorf("loading (%w) failed", pathErr)
+	require.EqualError(t, err, "loading (open a.txt: file does not exist) failed")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	var target *os.PathError
+	require.ErrorAs(t, err, &target)
+	require.NotEmpty(t, errors.Stack(err))
+
+	inner := errors.New("boom")
+	err = errors.Errorf("%w happened", inner)
+	require.Equal(t, errors.Stack(inner), errors.Stack(err))
+	require.Regexp(t, `^boom happened\nboom\n`, fmt.Sprintf("%+v", err))
+	require.Regexp(t, `\.TestErrorfCauseInTheMiddle\n\t.+wrapf_test\.go:\d+: boom happened\n$`, fmt.Sprintf("%+v", err))
+
+	data, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+	require.Contains(t, string(data), `"at":`)
+}
+
+func TestErrorfMultipleCauses(t *testing.T) {
+	inner := errors.New("boom")
+	err := errors.Errorf("sync: %w, %w", io.EOF, inner)
+	require.EqualError(t, err, "sync: EOF, boom")
+	require.ErrorIs(t, err, io.EOF)
+	require.ErrorIs(t, err, inner)
+	require.Len(t, errors.Joined(err), 2)
+	require.Equal(t, errors.Stack(inner), errors.Stack(err))
+
+	got := fmt.Sprintf("%+v", err)
+	require.Regexp(t, `^sync: EOF, boom\n  \[1\] EOF\n  \[2\] boom\n`, got)
+	require.Regexp(t, `\.TestErrorfMultipleCauses\n\t.+wrapf_test\.go:\d+: sync: EOF, boom\n$`, got)
+
+	err = errors.Errorf("sync: %w, %w", io.EOF, io.ErrUnexpectedEOF)
+	require.Equal(t, errors.PkgName+".TestErrorfMultipleCauses", funcName(errors.Stack(err)[0]))
+}
+
+func TestErrorfWithoutWrapping(t *testing.T) {
+	err := errors.Errorf("plain %d", 42)
+	require.EqualError(t, err, "plain 42")
+	require.Nil(t, errors.Unwrap(err))
+	require.NotEmpty(t, errors.Stack(err))
+
+	err = errors.Errorf("nil: %w", nil)
+	require.EqualError(t, err, "nil: %!w(<nil>)")
+	require.Nil(t, errors.Unwrap(err))
+}
+
+func TestWrapfOperands(t *testing.T) {
+	require.NoError(t, errors.Wrapf(nil, "retry after %w", io.EOF))
+
+	err := errors.Wrapf(io.ErrUnexpectedEOF, "retry after %w", io.EOF)
+	require.EqualError(t, err, "retry after EOF: unexpected EOF")
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []error{io.ErrUnexpectedEOF, io.EOF}, errors.Joined(err))
+	require.Equal(t, errors.PkgName+".TestWrapfOperands", funcName(errors.Stack(err)[0]))
+	require.Equal(t, "1. retry after EOF", errors.Explain(err))
+
+	// without %w Wrapf behaves as before
+	err = errors.Wrapf(io.EOF, "read %s", "a.txt")
+	require.EqualError(t, err, "read a.txt: EOF")
+	require.Nil(t, errors.Joined(err))
+}
+
+func TestReplaceCauseErrorf(t *testing.T) {
+	err := errors.Errorf("loading (%w) failed", io.EOF)
+	replaced := errors.ReplaceCause(err, io.ErrUnexpectedEOF)
+	require.EqualError(t, replaced, "loading (unexpected EOF) failed")
+	require.ErrorIs(t, replaced, io.ErrUnexpectedEOF)
+	require.NotErrorIs(t, replaced, io.EOF)
+}
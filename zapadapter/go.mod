@@ -0,0 +1,16 @@
+module github.com/quenbyako/errors/zapadapter
+
+go 1.20
+
+require (
+	github.com/quenbyako/errors v0.0.0-20261016165627-c6d3347d4d0a
+	github.com/stretchr/testify v1.8.1
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,154 @@
+// Package zapadapter provides go.uber.org/zap integration for errors of
+// github.com/quenbyako/errors package: errors are logged as structured
+// objects with message, cause chain, fields and stack frames, instead of
+// giant %+v strings.
+//
+//	logger.Error("request failed", zapadapter.Field(err))
+package zapadapter
+
+import (
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/quenbyako/errors"
+)
+
+// Field returns zap field with "error" key, which contains structured
+// representation of err (see Object). If err is nil, Field returns no-op
+// field.
+func Field(err error) zap.Field { return NamedField("error", err) }
+
+// NamedField is like Field, but with custom key.
+func NamedField(key string, err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Object(key, Object(err))
+}
+
+// Level returns zap level of err according to its severity (see
+// errors.Severity): WarnLevel for warnings and ErrorLevel for others. Zap
+// levels above ErrorLevel panic or exit, so critical errors are logged as
+// errors, but their "severity" key (see Object) lets alerting distinguish
+// them. If err is nil, Level returns InfoLevel.
+//
+//	logger.Log(zapadapter.Level(err), "request failed", zapadapter.Field(err))
+func Level(err error) zapcore.Level {
+	switch {
+	case err == nil:
+		return zapcore.InfoLevel
+	case errors.Severity(err) == errors.LevelWarning:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// Object returns zapcore.ObjectMarshaler of err, which emits:
+//
+//   - "msg": message of err;
+//   - "chain": own messages of each error in the chain, if there are more
+//     than one;
+//   - "kind" and "code": kind and code of err, if any;
+//   - "severity": severity of err, if it's not errors.LevelError;
+//   - "fields": structured fields of err (see errors.Fields);
+//   - "stack": frames of err's stack trace as objects with "func", "file"
+//     and "line" keys.
+func Object(err error) zapcore.ObjectMarshaler { return object{err} }
+
+type object struct{ err error }
+
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("msg", o.err.Error())
+
+	if chain := messageChain(o.err); len(chain) > 1 {
+		if err := enc.AddArray("chain", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			for _, msg := range chain {
+				enc.AppendString(msg)
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	if kind := errors.KindOf(o.err); kind != errors.KindUnknown {
+		enc.AddString("kind", kind.String())
+	}
+	if code, ok := errors.Code(o.err); ok {
+		enc.AddInt("code", code)
+	}
+	if level := errors.Severity(o.err); level != errors.LevelError {
+		enc.AddString("severity", level.String())
+	}
+
+	if fields := errors.Fields(o.err); len(fields) > 0 {
+		if err := enc.AddObject("fields", fieldsObject(fields)); err != nil {
+			return err
+		}
+	}
+
+	if stack := errors.Stack(o.err); len(stack) > 0 {
+		return enc.AddArray("stack", stackArray(stack))
+	}
+	return nil
+}
+
+// messageChain returns own messages of errors in err's chain: message of
+// each error without message of its cause. Chain ends at truncated error
+// (see errors.Truncate), so messages of its causes are not logged in full.
+func messageChain(err error) []string {
+	var res []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		if errors.IsTruncated(err) {
+			res = append(res, err.Error())
+			break
+		}
+		msg := err.Error()
+		if cause := errors.Unwrap(err); cause != nil {
+			msg = strings.TrimSuffix(strings.TrimSuffix(msg, cause.Error()), ": ")
+		}
+		if msg != "" {
+			res = append(res, msg)
+		}
+	}
+	return res
+}
+
+type fieldsObject map[string]interface{}
+
+func (f fieldsObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		zap.Any(k, f[k]).AddTo(enc)
+	}
+	return nil
+}
+
+type stackArray errors.StackTrace
+
+func (st stackArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, f := range st {
+		if err := enc.AppendObject(frameObject(f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type frameObject errors.Frame
+
+func (f frameObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	file, line, name := errors.Frame(f).FuncInfo()
+	enc.AddString("func", name)
+	enc.AddString("file", file)
+	enc.AddInt("line", line)
+	return nil
+}
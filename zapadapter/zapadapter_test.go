@@ -0,0 +1,87 @@
+package zapadapter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/quenbyako/errors"
+	"github.com/quenbyako/errors/zapadapter"
+)
+
+func logJSON(t *testing.T, fields ...zap.Field) map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "M"}),
+		zapcore.AddSync(&buf),
+		zap.DebugLevel,
+	)
+	zap.New(core).Error("failed", fields...)
+
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	return res
+}
+
+func TestField(t *testing.T) {
+	err := errors.Wrap(
+		errors.WithKind(errors.WithField(errors.WithCode(errors.New("no rows"), 42), "user_id", 7), errors.KindNotFound),
+		"get user",
+	)
+
+	got := logJSON(t, zapadapter.Field(err))["error"].(map[string]interface{})
+
+	require.Equal(t, "get user: no rows", got["msg"])
+	require.Equal(t, []interface{}{"get user", "no rows"}, got["chain"])
+	require.Equal(t, "not_found", got["kind"])
+	require.Equal(t, float64(42), got["code"])
+	require.Equal(t, map[string]interface{}{"user_id": float64(7)}, got["fields"])
+
+	stack := got["stack"].([]interface{})
+	require.Len(t, stack, len(errors.Stack(err)))
+	frame := stack[0].(map[string]interface{})
+	require.Equal(t, "github.com/quenbyako/errors/zapadapter_test.TestField", frame["func"])
+	require.Regexp(t, `zapadapter_test\.go$`, frame["file"])
+	require.NotZero(t, frame["line"])
+}
+
+func TestFieldTruncated(t *testing.T) {
+	payload := strings.Repeat("x", 100)
+	err := errors.Wrap(errors.Truncate(errors.Wrap(io.EOF, "query "+payload), errors.Limits{Message: 10}), "get user")
+
+	got := logJSON(t, zapadapter.Field(err))["error"].(map[string]interface{})
+
+	require.Equal(t, []interface{}{"get user", "query xxxx... (truncated, 111 bytes total)"}, got["chain"])
+	require.NotContains(t, got["msg"], payload)
+}
+
+func TestFieldForeign(t *testing.T) {
+	got := logJSON(t, zapadapter.NamedField("cause", io.EOF))
+
+	require.Equal(t, map[string]interface{}{"msg": "EOF"}, got["cause"])
+}
+
+func TestFieldNil(t *testing.T) {
+	got := logJSON(t, zapadapter.Field(nil))
+
+	require.NotContains(t, got, "error")
+}
+
+func TestLevel(t *testing.T) {
+	require.Equal(t, zapcore.InfoLevel, zapadapter.Level(nil))
+	require.Equal(t, zapcore.ErrorLevel, zapadapter.Level(io.EOF))
+	require.Equal(t, zapcore.WarnLevel, zapadapter.Level(errors.Warn(io.EOF)))
+	require.Equal(t, zapcore.ErrorLevel, zapadapter.Level(errors.Critical(io.EOF)))
+
+	got := logJSON(t, zapadapter.Field(errors.Critical(io.EOF)))["error"].(map[string]interface{})
+	require.Equal(t, "critical", got["severity"])
+	got = logJSON(t, zapadapter.Field(io.EOF))["error"].(map[string]interface{})
+	require.NotContains(t, got, "severity")
+}